@@ -0,0 +1,188 @@
+package byteplus
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
+	"fmt"
+	"strings"
+	"time"
+
+	byteplusCdnClient "github.com/byteplus-sdk/byteplus-sdk-golang/service/cdn"
+)
+
+const (
+	// The source of the certificates hosted by CDN.
+	cdnCertSourceCdn = "cdn_cert_hosting"
+)
+
+// parseCertificateChain parses the certificates in the PEM data, the leaf
+// certificate comes first followed by the intermediate certificates.
+//
+// Parameters:
+//   - pemData: The PEM encoded certificates.
+//
+// Returns:
+//   - certs: The certificates in the order of the PEM data.
+//   - err: Error of decoding or parsing the certificates.
+func parseCertificateChain(pemData string) (certs []*x509.Certificate, err error) {
+	rest := []byte(pemData)
+	for {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			return nil, fmt.Errorf("unexpected PEM block %q, only CERTIFICATE blocks are allowed", block.Type)
+		}
+
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse certificate %d: %w", len(certs)+1, err)
+		}
+		certs = append(certs, cert)
+	}
+
+	if len(bytes.TrimSpace(rest)) > 0 {
+		return nil, fmt.Errorf("unexpected data after certificate %d, the data is not PEM encoded", len(certs))
+	}
+	if len(certs) == 0 {
+		return nil, fmt.Errorf("no certificate is found in the PEM data")
+	}
+
+	return certs, nil
+}
+
+// validateCertificateKeyPair validates the private key matches the leaf
+// certificate, which is rejected by the API with
+// InvalidParameter.Certificate.KeyNotMatch.
+func validateCertificateKeyPair(certificate, privateKey string) error {
+	if _, err := tls.X509KeyPair([]byte(certificate), []byte(privateKey)); err != nil {
+		return fmt.Errorf("the private key does not match the certificate (%s): %w",
+			ERR_CODE_INVALID_PARAMETER_CERTIFICATE_KEY_NOT_MATCH, err)
+	}
+
+	return nil
+}
+
+// validateCertificateChain validates the certificates form a complete chain,
+// which is rejected by the API with InvalidParameter.Https.CertInfo.ChainMissing
+// otherwise. Each certificate must be signed by the next one, and the last
+// certificate must either be self-signed or issued by a root trusted by the
+// system.
+//
+// Parameters:
+//   - certs: The certificates with the leaf certificate first.
+//   - now: The time to check the validity of the leaf certificate.
+//
+// Returns:
+//   - err: The chain is incomplete or the leaf certificate is not valid at
+//     the time.
+func validateCertificateChain(certs []*x509.Certificate, now time.Time) error {
+	leaf := certs[0]
+	if now.After(leaf.NotAfter) {
+		return fmt.Errorf("the certificate %s has expired at %s", leaf.Subject, leaf.NotAfter.UTC().Format(time.RFC3339))
+	}
+
+	for i := 0; i < len(certs)-1; i++ {
+		if err := certs[i].CheckSignatureFrom(certs[i+1]); err != nil {
+			return fmt.Errorf("the certificate %s is not signed by the next certificate %s in the chain (%s): %w",
+				certs[i].Subject, certs[i+1].Subject, ERR_CODE_INVALID_PARAMETER_HTTPS_CERT_INFO_CHAIN_MISSING, err)
+		}
+	}
+
+	last := certs[len(certs)-1]
+	if bytes.Equal(last.RawIssuer, last.RawSubject) && last.CheckSignatureFrom(last) == nil {
+		return nil
+	}
+
+	roots, err := x509.SystemCertPool()
+	if err != nil {
+		// The chain cannot be checked against the trusted roots.
+		return nil
+	}
+
+	_, err = last.Verify(x509.VerifyOptions{
+		Roots:       roots,
+		CurrentTime: now,
+		KeyUsages:   []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+	})
+	if err != nil {
+		return fmt.Errorf("the issuer %s of the certificate %s is missing in the chain (%s): %w",
+			last.Issuer, last.Subject, ERR_CODE_INVALID_PARAMETER_HTTPS_CERT_INFO_CHAIN_MISSING, err)
+	}
+
+	return nil
+}
+
+// certificateSubjectAlternativeNames returns the DNS names and IP addresses
+// of the certificate.
+func certificateSubjectAlternativeNames(cert *x509.Certificate) []string {
+	names := make([]string, 0, len(cert.DNSNames)+len(cert.IPAddresses))
+	names = append(names, cert.DNSNames...)
+	for _, ip := range cert.IPAddresses {
+		names = append(names, ip.String())
+	}
+
+	return names
+}
+
+// certificateFingerprint returns the SHA-256 fingerprint of the certificate
+// in lower case hex.
+func certificateFingerprint(cert *x509.Certificate) string {
+	sum := sha256.Sum256(cert.Raw)
+	return hex.EncodeToString(sum[:])
+}
+
+// certificateSha1Fingerprint returns the SHA-1 fingerprint of the certificate
+// in lower case hex, as shown in the console of CDN.
+func certificateSha1Fingerprint(cert *x509.Certificate) string {
+	sum := sha1.Sum(cert.Raw)
+	return hex.EncodeToString(sum[:])
+}
+
+// certificateCoversDomain returns whether the certificate is valid for the
+// domain name, a wildcard name covers a single label only, e.g.
+// *.example.com covers www.example.com but neither example.com nor
+// a.b.example.com.
+func certificateCoversDomain(cert *x509.Certificate, domainName string) bool {
+	return cert.VerifyHostname(strings.TrimSuffix(domainName, ".")) == nil
+}
+
+// describeCdnCertificate returns the certificate hosted by CDN.
+//
+// Parameters:
+//   - ctx: Context.
+//   - client: The CDN client.
+//   - certId: The ID of the certificate.
+//
+// Returns:
+//   - cert: The certificate, nil if the certificate is not found.
+//   - err: Error of listing the certificates.
+func describeCdnCertificate(ctx context.Context, client *byteplusCdnClient.CDN, certId string) (cert *byteplusCdnClient.ListCertInfo, err error) {
+	var response *byteplusCdnClient.ListCdnCertInfoResponse
+	err = retryCdnApiCall(ctx, client, "ListCdnCertInfo", func() (err error) {
+		response, err = client.ListCdnCertInfo(&byteplusCdnClient.ListCdnCertInfoRequest{
+			CertId: byteplusCdnClient.GetStrPtr(certId),
+			Source: byteplusCdnClient.GetStrPtr(cdnCertSourceCdn),
+		})
+		return
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, certInfo := range response.Result.CertInfo {
+		if certInfo.CertId == certId {
+			return &certInfo, nil
+		}
+	}
+
+	return nil, nil
+}
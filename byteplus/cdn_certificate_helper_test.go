@@ -0,0 +1,214 @@
+package byteplus
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"strings"
+	"testing"
+	"time"
+)
+
+// testCertificate is a certificate with its private key issued for the
+// tests.
+type testCertificate struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+}
+
+func (c testCertificate) certPem() string {
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: c.cert.Raw}))
+}
+
+func (c testCertificate) keyPem(t *testing.T) string {
+	t.Helper()
+
+	der, err := x509.MarshalECPrivateKey(c.key)
+	if err != nil {
+		t.Fatalf("failed to marshal private key: %v", err)
+	}
+
+	return string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: der}))
+}
+
+// issueTestCertificate issues a certificate signed by the issuer, or a
+// self-signed CA certificate if the issuer is nil.
+func issueTestCertificate(t *testing.T, template *x509.Certificate, issuer *testCertificate) testCertificate {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate private key: %v", err)
+	}
+
+	template.SerialNumber = big.NewInt(time.Now().UnixNano())
+	if template.NotBefore.IsZero() {
+		template.NotBefore = time.Now().Add(-time.Hour)
+	}
+	if template.NotAfter.IsZero() {
+		template.NotAfter = time.Now().Add(24 * time.Hour)
+	}
+
+	parent, signer := template, key
+	if issuer != nil {
+		parent, signer = issuer.cert, issuer.key
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, &key.PublicKey, signer)
+	if err != nil {
+		t.Fatalf("failed to create certificate: %v", err)
+	}
+
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatalf("failed to parse certificate: %v", err)
+	}
+
+	return testCertificate{cert: cert, key: key}
+}
+
+func newTestCa(t *testing.T, commonName string, issuer *testCertificate) testCertificate {
+	return issueTestCertificate(t, &x509.Certificate{
+		Subject:               pkix.Name{CommonName: commonName},
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}, issuer)
+}
+
+func TestParseCertificateChain(t *testing.T) {
+	root := newTestCa(t, "Test Root CA", nil)
+	intermediate := newTestCa(t, "Test Intermediate CA", &root)
+	keyPem := intermediate.keyPem(t)
+
+	tests := []struct {
+		name      string
+		pemData   string
+		wantCerts int
+		wantError string
+	}{
+		{name: "single certificate", pemData: root.certPem(), wantCerts: 1},
+		{name: "chain", pemData: intermediate.certPem() + root.certPem(), wantCerts: 2},
+		{name: "private key block", pemData: intermediate.certPem() + keyPem, wantError: "only CERTIFICATE blocks"},
+		{name: "trailing data", pemData: root.certPem() + "not pem", wantError: "not PEM encoded"},
+		{name: "empty", pemData: "", wantError: "no certificate"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			certs, err := parseCertificateChain(tt.pemData)
+			if tt.wantError != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantError) {
+					t.Errorf("error = %v, want %q", err, tt.wantError)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(certs) != tt.wantCerts {
+				t.Errorf("certificates = %d, want %d", len(certs), tt.wantCerts)
+			}
+		})
+	}
+}
+
+func TestValidateCertificateKeyPair(t *testing.T) {
+	root := newTestCa(t, "Test Root CA", nil)
+	other := newTestCa(t, "Other Root CA", nil)
+
+	if err := validateCertificateKeyPair(root.certPem(), root.keyPem(t)); err != nil {
+		t.Errorf("matching key: unexpected error: %v", err)
+	}
+
+	err := validateCertificateKeyPair(root.certPem(), other.keyPem(t))
+	if err == nil || !strings.Contains(err.Error(), ERR_CODE_INVALID_PARAMETER_CERTIFICATE_KEY_NOT_MATCH) {
+		t.Errorf("mismatched key: error = %v, want %s", err, ERR_CODE_INVALID_PARAMETER_CERTIFICATE_KEY_NOT_MATCH)
+	}
+}
+
+func TestValidateCertificateChain(t *testing.T) {
+	root := newTestCa(t, "Test Root CA", nil)
+	intermediate := newTestCa(t, "Test Intermediate CA", &root)
+	leaf := issueTestCertificate(t, &x509.Certificate{
+		Subject:  pkix.Name{CommonName: "www.example.com"},
+		DNSNames: []string{"www.example.com"},
+	}, &intermediate)
+	expired := issueTestCertificate(t, &x509.Certificate{
+		Subject:   pkix.Name{CommonName: "expired.example.com"},
+		DNSNames:  []string{"expired.example.com"},
+		NotBefore: time.Now().Add(-48 * time.Hour),
+		NotAfter:  time.Now().Add(-24 * time.Hour),
+	}, &intermediate)
+
+	tests := []struct {
+		name      string
+		certs     []*x509.Certificate
+		wantError string
+	}{
+		{name: "complete chain", certs: []*x509.Certificate{leaf.cert, intermediate.cert, root.cert}},
+		{name: "self-signed", certs: []*x509.Certificate{root.cert}},
+		{name: "missing intermediate", certs: []*x509.Certificate{leaf.cert, root.cert}, wantError: ERR_CODE_INVALID_PARAMETER_HTTPS_CERT_INFO_CHAIN_MISSING},
+		{name: "untrusted root not included", certs: []*x509.Certificate{leaf.cert, intermediate.cert}, wantError: ERR_CODE_INVALID_PARAMETER_HTTPS_CERT_INFO_CHAIN_MISSING},
+		{name: "wrong order", certs: []*x509.Certificate{leaf.cert, root.cert, intermediate.cert}, wantError: ERR_CODE_INVALID_PARAMETER_HTTPS_CERT_INFO_CHAIN_MISSING},
+		{name: "expired leaf", certs: []*x509.Certificate{expired.cert, intermediate.cert, root.cert}, wantError: "has expired"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateCertificateChain(tt.certs, time.Now())
+			if tt.wantError == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+
+			if err == nil || !strings.Contains(err.Error(), tt.wantError) {
+				t.Errorf("error = %v, want %q", err, tt.wantError)
+			}
+		})
+	}
+}
+
+func TestCertificateCoversDomain(t *testing.T) {
+	root := newTestCa(t, "Test Root CA", nil)
+	cert := issueTestCertificate(t, &x509.Certificate{
+		Subject:     pkix.Name{CommonName: "*.example.com"},
+		DNSNames:    []string{"*.example.com", "example.org"},
+		IPAddresses: []net.IP{net.ParseIP("192.0.2.1")},
+	}, &root)
+
+	tests := []struct {
+		domainName string
+		want       bool
+	}{
+		{domainName: "www.example.com", want: true},
+		{domainName: "WWW.Example.com", want: true},
+		{domainName: "www.example.com.", want: true},
+		{domainName: "example.com", want: false},
+		{domainName: "a.b.example.com", want: false},
+		{domainName: "example.org", want: true},
+		{domainName: "www.example.org", want: false},
+		{domainName: "192.0.2.1", want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.domainName, func(t *testing.T) {
+			if got := certificateCoversDomain(cert.cert, tt.domainName); got != tt.want {
+				t.Errorf("certificateCoversDomain(%q) = %v, want %v", tt.domainName, got, tt.want)
+			}
+		})
+	}
+
+	wantNames := "*.example.com,example.org,192.0.2.1"
+	if got := strings.Join(certificateSubjectAlternativeNames(cert.cert), ","); got != wantNames {
+		t.Errorf("subject alternative names = %q, want %q", got, wantNames)
+	}
+}
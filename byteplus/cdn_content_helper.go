@@ -0,0 +1,346 @@
+package byteplus
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	byteplusCdnClient "github.com/byteplus-sdk/byteplus-sdk-golang/service/cdn"
+	"github.com/cenkalti/backoff/v4"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+)
+
+const (
+	cdnRefreshTypeFile = "file"
+	cdnRefreshTypeDir  = "dir"
+
+	// The task types of DescribeContentTasks API.
+	cdnContentTaskTypeRefreshFile = "refresh_file"
+	cdnContentTaskTypeRefreshDir  = "refresh_dir"
+	cdnContentTaskTypePreload     = "preload"
+
+	cdnContentTaskStatusRunning  = "running"
+	cdnContentTaskStatusComplete = "complete"
+	cdnContentTaskStatusFailed   = "failed"
+
+	// The maximum number of URLs in a single SubmitRefreshTask or
+	// SubmitPreloadTask request.
+	cdnRefreshFileUrlsPerRequest = 1000
+	cdnRefreshDirUrlsPerRequest  = 50
+	cdnPreloadUrlsPerRequest     = 1000
+
+	// The page size of listing the URLs of content tasks.
+	cdnContentTasksPageSize = 100
+
+	// The default timeout of waiting for content tasks to complete.
+	cdnContentTaskTimeout = 20 * time.Minute
+)
+
+var cdnRefreshTypes = []string{cdnRefreshTypeFile, cdnRefreshTypeDir}
+
+// cdnContentQuota is the daily quota of a type of content tasks.
+type cdnContentQuota struct {
+	limit  int64
+	remain int64
+}
+
+// describeCdnContentQuota returns the daily quota of the type of content
+// tasks.
+//
+// Parameters:
+//   - ctx: Context.
+//   - client: The CDN client.
+//   - taskType: The task type, `refresh_file`, `refresh_dir` or `preload`.
+//
+// Returns:
+//   - quota: The daily quota of the task type.
+//   - err: Error of describing the quota.
+func describeCdnContentQuota(ctx context.Context, client *byteplusCdnClient.CDN, taskType string) (quota cdnContentQuota, err error) {
+	var response *byteplusCdnClient.DescribeContentQuotaResponse
+	err = retryCdnApiCall(ctx, client, "DescribeContentQuota", func() (err error) {
+		response, err = client.DescribeContentQuota()
+		return
+	})
+	if err != nil {
+		return quota, err
+	}
+
+	result := response.Result
+	switch taskType {
+	case cdnContentTaskTypeRefreshFile:
+		return cdnContentQuota{limit: result.RefreshQuota, remain: result.RefreshRemain}, nil
+	case cdnContentTaskTypeRefreshDir:
+		return cdnContentQuota{limit: result.RefreshDirQuota, remain: result.RefreshDirRemain}, nil
+	default:
+		return cdnContentQuota{limit: result.PreloadQuota, remain: result.PreloadRemain}, nil
+	}
+}
+
+// checkCdnContentQuota returns an error if the remaining daily quota of the
+// type of content tasks is not enough for the URLs, so no task is submitted
+// when only part of the URLs can be submitted.
+//
+// Parameters:
+//   - ctx: Context.
+//   - client: The CDN client.
+//   - taskType: The task type, `refresh_file`, `refresh_dir` or `preload`.
+//   - urlCount: The number of URLs to submit.
+//
+// Returns:
+//   - err: Error of describing the quota, or the quota is exhausted.
+func checkCdnContentQuota(ctx context.Context, client *byteplusCdnClient.CDN, taskType string, urlCount int) (err error) {
+	quota, err := describeCdnContentQuota(ctx, client, taskType)
+	if err != nil {
+		return err
+	}
+
+	if quota.remain < int64(urlCount) {
+		return newCdnContentQuotaExceededError(taskType, quota, urlCount)
+	}
+
+	return nil
+}
+
+func newCdnContentQuotaExceededError(taskType string, quota cdnContentQuota, urlCount int) error {
+	return fmt.Errorf("the daily quota of %s tasks is exhausted, %d URLs are required "+
+		"but only %d of %d remain today. The quota is reset daily, retry tomorrow or "+
+		"reduce the URLs", taskType, urlCount, quota.remain, quota.limit)
+}
+
+// isCdnQuotaExceededError returns whether the error is caused by the daily
+// quota of content tasks is exhausted.
+func isCdnQuotaExceededError(err error) bool {
+	byteErr, ok := err.(byteplusCdnClient.CDNError)
+	return ok && byteErr.Code == ERR_CODE_QUOTA_EXCEEDED_TODAY
+}
+
+// submitCdnContentTasks submits the URLs in batches within the limit of a
+// single request.
+//
+// Parameters:
+//   - ctx: Context.
+//   - client: The CDN client.
+//   - action: The API action name.
+//   - urls: The URLs to submit.
+//   - batchSize: The maximum number of URLs in a single request.
+//   - submit: The SDK call to submit a batch of URLs separated by newlines,
+//     returns the task ID.
+//
+// Returns:
+//   - taskIds: The IDs of the submitted tasks, one for each batch.
+//   - err: Error of submitting the tasks or the daily quota is exhausted, the
+//     tasks submitted before the error are still returned.
+func submitCdnContentTasks(ctx context.Context, client *byteplusCdnClient.CDN, action string, urls []string, batchSize int, submit func(urls string) (string, error)) (taskIds []string, err error) {
+	submitted := 0
+	for _, batch := range chunkStrings(urls, batchSize) {
+		var taskId string
+		err = retryCdnApiCall(ctx, client, action, func() (err error) {
+			taskId, err = submit(strings.Join(batch, "\n"))
+			return
+		})
+		if isCdnQuotaExceededError(err) {
+			return taskIds, fmt.Errorf("the daily quota is exhausted after %d of %d URLs are "+
+				"submitted with %s. The quota is reset daily, retry tomorrow or reduce the URLs.\n\n%s",
+				submitted, len(urls), action, err.Error())
+		}
+		if err != nil {
+			return taskIds, err
+		}
+
+		taskIds = append(taskIds, taskId)
+		submitted += len(batch)
+	}
+
+	return taskIds, nil
+}
+
+// describeCdnContentTasks returns the URLs of the content task with their
+// status, the pages are listed until all the URLs are returned.
+//
+// Parameters:
+//   - ctx: Context.
+//   - client: The CDN client.
+//   - taskType: The task type, `refresh_file`, `refresh_dir` or `preload`.
+//   - taskId: The ID of the task.
+//
+// Returns:
+//   - tasks: The URLs of the task.
+//   - err: Error of describing the task.
+func describeCdnContentTasks(ctx context.Context, client *byteplusCdnClient.CDN, taskType, taskId string) (tasks []byteplusCdnClient.ContentTask, err error) {
+	request := &byteplusCdnClient.DescribeContentTasksRequest{
+		TaskType: taskType,
+		TaskID:   byteplusCdnClient.GetStrPtr(taskId),
+		PageSize: byteplusCdnClient.GetInt64Ptr(cdnContentTasksPageSize),
+	}
+
+	for pageNum := int64(1); ; pageNum++ {
+		request.PageNum = byteplusCdnClient.GetInt64Ptr(pageNum)
+
+		var response *byteplusCdnClient.DescribeContentTasksResponse
+		err = retryCdnApiCall(ctx, client, "DescribeContentTasks", func() (err error) {
+			response, err = client.DescribeContentTasks(request)
+			return
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		tasks = append(tasks, response.Result.Data...)
+		if len(response.Result.Data) < cdnContentTasksPageSize || int64(len(tasks)) >= response.Result.Total {
+			return tasks, nil
+		}
+	}
+}
+
+// waitForCdnContentTasks polls the content tasks with backoff until none of
+// their URLs is running.
+//
+// Parameters:
+//   - ctx: Context.
+//   - client: The CDN client.
+//   - taskType: The task type, `refresh_file`, `refresh_dir` or `preload`.
+//   - taskIds: The IDs of the tasks.
+//   - timeout: The maximum time to wait.
+//
+// Returns:
+//   - tasks: The URLs of all the tasks with their final status.
+//   - err: Error of describing the tasks, or the tasks are still running
+//     after timeout.
+func waitForCdnContentTasks(ctx context.Context, client *byteplusCdnClient.CDN, taskType string, taskIds []string, timeout time.Duration) (tasks []byteplusCdnClient.ContentTask, err error) {
+	checkTasks := func() error {
+		tasks = nil
+		for _, taskId := range taskIds {
+			taskUrls, err := describeCdnContentTasks(ctx, client, taskType, taskId)
+			if err != nil {
+				return backoff.Permanent(err)
+			}
+
+			for _, taskUrl := range taskUrls {
+				if taskUrl.Status == cdnContentTaskStatusRunning {
+					return fmt.Errorf("URL %s of %s task %s is running, waiting for completion", taskUrl.Url, taskType, taskId)
+				}
+			}
+
+			tasks = append(tasks, taskUrls...)
+		}
+
+		return nil
+	}
+
+	taskBackoff := backoff.NewExponentialBackOff()
+	taskBackoff.MaxInterval = 30 * time.Second
+	taskBackoff.MaxElapsedTime = timeout
+	err = backoff.Retry(checkTasks, backoff.WithContext(taskBackoff, ctx))
+	return
+}
+
+// cdnContentTaskRun describes how to submit a type of content tasks.
+type cdnContentTaskRun struct {
+	// The operation shown in the diagnostic titles, `Refresh` or `Preload`.
+	operation string
+	action    string
+	taskType  string
+	batchSize int
+	submit    func(urls string) (string, error)
+}
+
+// cdnContentTasksResult is the outcome of runCdnContentTasks.
+type cdnContentTasksResult struct {
+	taskIds []string
+	tasks   []byteplusCdnClient.ContentTask
+	quota   *cdnContentQuota
+}
+
+// runCdnContentTasks submits the URLs as content tasks and checks their
+// results, it is shared by the refresh and preload resources so both fail in
+// the same way:
+//
+//   - Nothing is submitted if the remaining daily quota is not enough.
+//   - Once a task is submitted, its ID is returned even with errors, so the
+//     caller records it in state. Failing to submit the rest of the URLs, to
+//     describe the tasks or any URL failed is an error, which taints the
+//     resource and submits all the URLs again on the next apply.
+//   - The tasks are waited until complete if wait is set, or described once
+//     otherwise, so the URLs still running are not checked.
+//   - Failing to describe the quota after submitting is only a warning.
+//
+// Parameters:
+//   - ctx: Context.
+//   - client: The CDN client.
+//   - run: The type of content tasks to submit.
+//   - urls: The URLs to submit.
+//   - wait: Whether to wait until the tasks complete.
+//   - waitTimeout: The maximum time to wait.
+//
+// Returns:
+//   - result: The submitted tasks, their URLs and the quota after submitting.
+//   - diags: Diagnostics of the failure policy above.
+func runCdnContentTasks(ctx context.Context, client *byteplusCdnClient.CDN, run cdnContentTaskRun, urls []string, wait bool, waitTimeout time.Duration) (result cdnContentTasksResult, diags diag.Diagnostics) {
+	if err := checkCdnContentQuota(ctx, client, run.taskType, len(urls)); err != nil {
+		diags.AddError(
+			fmt.Sprintf("[API ERROR] Failed to %s CDN Caches.", run.operation),
+			err.Error(),
+		)
+		return
+	}
+
+	var err error
+	result.taskIds, err = submitCdnContentTasks(ctx, client, run.action, urls, run.batchSize, run.submit)
+	if err != nil {
+		diags.AddError(
+			fmt.Sprintf("[API ERROR] Failed to %s CDN Caches.", run.operation),
+			err.Error(),
+		)
+		return
+	}
+
+	if wait {
+		result.tasks, err = waitForCdnContentTasks(ctx, client, run.taskType, result.taskIds, waitTimeout)
+		if err != nil {
+			diags.AddError(
+				fmt.Sprintf("[API ERROR] Failed to Wait for CDN %s Tasks.", run.operation),
+				err.Error(),
+			)
+			return
+		}
+	} else {
+		for _, taskId := range result.taskIds {
+			taskUrls, err := describeCdnContentTasks(ctx, client, run.taskType, taskId)
+			if err != nil {
+				diags.AddError(
+					fmt.Sprintf("[API ERROR] Failed to Describe CDN %s Tasks.", run.operation),
+					err.Error(),
+				)
+				return
+			}
+			result.tasks = append(result.tasks, taskUrls...)
+		}
+	}
+
+	var failedUrls []string
+	for _, task := range result.tasks {
+		if task.Status == cdnContentTaskStatusFailed {
+			failedUrls = append(failedUrls, task.Url)
+		}
+	}
+	if len(failedUrls) > 0 {
+		diags.AddError(
+			fmt.Sprintf("Failed to %s CDN Caches.", run.operation),
+			fmt.Sprintf("The %s of the following URLs failed:\n\n%s",
+				strings.ToLower(run.operation), strings.Join(failedUrls, "\n")),
+		)
+	}
+
+	quota, err := describeCdnContentQuota(ctx, client, run.taskType)
+	if err != nil {
+		diags.AddWarning(
+			"[API ERROR] Failed to Describe CDN Content Quota.",
+			err.Error(),
+		)
+		return
+	}
+	result.quota = &quota
+
+	return
+}
@@ -0,0 +1,126 @@
+package byteplus
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	byteplusBaseClient "github.com/byteplus-sdk/byteplus-sdk-golang/base"
+	byteplusCdnClient "github.com/byteplus-sdk/byteplus-sdk-golang/service/cdn"
+)
+
+func TestSubmitCdnContentTasks(t *testing.T) {
+	urls := []string{
+		"https://www.example.com/1", "https://www.example.com/2", "https://www.example.com/3",
+		"https://www.example.com/4", "https://www.example.com/5",
+	}
+
+	tests := []struct {
+		name          string
+		quotaAfter    int
+		wantTaskIds   []string
+		wantSubmitted string
+	}{
+		{name: "all submitted", quotaAfter: -1, wantTaskIds: []string{"task-1", "task-2", "task-3"}},
+		{name: "quota exhausted on the last batch", quotaAfter: 2, wantTaskIds: []string{"task-1", "task-2"}, wantSubmitted: "after 4 of 5 URLs"},
+		{name: "quota exhausted on the first batch", quotaAfter: 0, wantTaskIds: nil, wantSubmitted: "after 0 of 5 URLs"},
+	}
+
+	client := newCdnClient(byteplusBaseClient.Credentials{}, "", nil)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := 0
+			taskIds, err := submitCdnContentTasks(context.Background(), client, "SubmitRefreshTask", urls, 2, func(batch string) (string, error) {
+				if calls == tt.quotaAfter {
+					return "", byteplusCdnClient.CDNError{Code: ERR_CODE_QUOTA_EXCEEDED_TODAY}
+				}
+				calls++
+				return fmt.Sprintf("task-%d", calls), nil
+			})
+
+			if fmt.Sprint(taskIds) != fmt.Sprint(tt.wantTaskIds) {
+				t.Errorf("task IDs = %v, want %v", taskIds, tt.wantTaskIds)
+			}
+			switch {
+			case tt.wantSubmitted == "" && err != nil:
+				t.Errorf("unexpected error: %v", err)
+			case tt.wantSubmitted != "" && (err == nil || !strings.Contains(err.Error(), tt.wantSubmitted)):
+				t.Errorf("error = %v, want %q", err, tt.wantSubmitted)
+			}
+		})
+	}
+}
+
+func TestRunCdnContentTasks(t *testing.T) {
+	urls := []string{"https://www.example.com/1", "https://www.example.com/2", "https://www.example.com/3"}
+
+	tests := []struct {
+		name          string
+		remain        int
+		status        string
+		wait          bool
+		quotaFails    bool
+		wantTaskIds   int
+		wantErrors    int
+		wantWarnings  int
+		wantQuotaNull bool
+	}{
+		{name: "completed", remain: 10, status: cdnContentTaskStatusComplete, wait: true, wantTaskIds: 2},
+		{name: "described once", remain: 10, status: cdnContentTaskStatusRunning, wantTaskIds: 2},
+		{name: "quota not enough", remain: 2, wantErrors: 1, wantQuotaNull: true},
+		{name: "failed URL", remain: 10, status: cdnContentTaskStatusFailed, wantTaskIds: 2, wantErrors: 1},
+		{name: "quota after submitting", remain: 10, status: cdnContentTaskStatusComplete, quotaFails: true, wantTaskIds: 2, wantWarnings: 1, wantQuotaNull: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			quotaCalls := 0
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch action := r.URL.Query().Get("Action"); action {
+				case "DescribeContentQuota":
+					quotaCalls++
+					if tt.quotaFails && quotaCalls > 1 {
+						_, _ = w.Write([]byte(`{"ResponseMetadata":{"RequestId":"1","Error":{"Code":"InternalError","Message":"internal"}}}`))
+						return
+					}
+					_, _ = fmt.Fprintf(w, `{"ResponseMetadata":{"RequestId":"1"},"Result":{"RefreshQuota":10,"RefreshRemain":%d}}`, tt.remain)
+				case "DescribeContentTasks":
+					_, _ = fmt.Fprintf(w, `{"ResponseMetadata":{"RequestId":"1"},"Result":{"Data":[{"Url":"https://www.example.com/1","TaskID":"task","Status":%q}],"Total":1}}`, tt.status)
+				default:
+					t.Errorf("unexpected action %q", action)
+				}
+			}))
+			defer server.Close()
+
+			client := newCdnClient(byteplusBaseClient.Credentials{}, server.URL, server.Client())
+			submitted := 0
+			result, diags := runCdnContentTasks(context.Background(), client, cdnContentTaskRun{
+				operation: "Refresh",
+				action:    "SubmitRefreshTask",
+				taskType:  cdnContentTaskTypeRefreshFile,
+				batchSize: 2,
+				submit: func(batch string) (string, error) {
+					submitted++
+					return fmt.Sprintf("task-%d", submitted), nil
+				},
+			}, urls, tt.wait, time.Minute)
+
+			if len(result.taskIds) != tt.wantTaskIds || submitted != tt.wantTaskIds {
+				t.Errorf("task IDs = %v, submitted = %d, want %d", result.taskIds, submitted, tt.wantTaskIds)
+			}
+			if errors := diags.ErrorsCount(); errors != tt.wantErrors {
+				t.Errorf("errors = %d, want %d: %v", errors, tt.wantErrors, diags)
+			}
+			if warnings := diags.WarningsCount(); warnings != tt.wantWarnings {
+				t.Errorf("warnings = %d, want %d: %v", warnings, tt.wantWarnings, diags)
+			}
+			if (result.quota == nil) != tt.wantQuotaNull {
+				t.Errorf("quota = %v, want null %t", result.quota, tt.wantQuotaNull)
+			}
+		})
+	}
+}
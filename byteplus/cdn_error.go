@@ -20,7 +20,11 @@ func isPermanentCdnError(errCode string) bool {
 		ERR_CODE_NOT_FOUND_DOMAIN,
 		ERR_CODE_IAM_UNAUTHORIZED,
 		ERR_CODE_SERVICE_STOPPED,
-		ERR_CODE_QUOTA_EXCEEDED_TODAY:
+		ERR_CODE_QUOTA_EXCEEDED_TODAY,
+		ERR_CODE_INVALID_PARAMETER_URLS,
+		ERR_CODE_INVALID_PARAMETER_CERTIFICATE,
+		ERR_CODE_INVALID_PARAMETER_CERTIFICATE_KEY_NOT_MATCH,
+		ERR_CODE_INVALID_PARAMETER_HTTPS_CERT_INFO_CHAIN_MISSING:
 		return true
 	default:
 		return false
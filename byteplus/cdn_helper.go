@@ -0,0 +1,413 @@
+package byteplus
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	byteplusCdnClient "github.com/byteplus-sdk/byteplus-sdk-golang/service/cdn"
+	"github.com/cenkalti/backoff/v4"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+)
+
+const (
+	cdnDomainStatusOnline          = "online"
+	cdnDomainStatusOffline         = "offline"
+	cdnDomainStatusConfiguring     = "configuring"
+	cdnDomainStatusConfigureFailed = "configure_failed"
+	cdnDomainStatusAuditFailed     = "audit_failed"
+
+	// The page size of listing CDN domains.
+	cdnDomainsPageSize = 100
+
+	// The resource type of CDN domains in TagResources and UntagResources API.
+	cdnResourceTypeDomain = "Domain"
+
+	// The default timeout of waiting for a CDN domain to reach a status.
+	cdnDomainStatusTimeout = 20 * time.Minute
+)
+
+// The statuses of CDN domains that can be filtered by.
+var cdnDomainStatuses = []string{
+	cdnDomainStatusOnline,
+	cdnDomainStatusOffline,
+	cdnDomainStatusConfiguring,
+	cdnDomainStatusConfigureFailed,
+	cdnDomainStatusAuditFailed,
+}
+
+// The CDN actions that are not idempotent. A request sent again after it has
+// been processed would add the domain or certificate twice, or submit the
+// tasks twice against the daily quota, so they are only retried if the
+// request is rejected before it is processed.
+var cdnNonIdempotentActions = map[string]bool{
+	"AddCdnDomain":      true,
+	"AddCdnCertificate": true,
+	"SubmitRefreshTask": true,
+	"SubmitPreloadTask": true,
+}
+
+// retryCdnApiCall executes and logs a call made with the CDN client, the call
+// is retried with backoff unless the error is permanent. Calls of the actions
+// that are not idempotent are only retried if the request is rejected before
+// it is processed, e.g. throttled, as a network error or timeout does not tell
+// whether the request has been processed.
+//
+// Parameters:
+//   - ctx: Context.
+//   - client: The CDN client.
+//   - action: The API action name.
+//   - call: The SDK call to execute.
+//
+// Returns:
+//   - err: The error of the last attempt.
+func retryCdnApiCall(ctx context.Context, client *byteplusCdnClient.CDN, action string, call func() error) (err error) {
+	attempt := 0
+	callApi := func() error {
+		attempt++
+		err := logCdnApiCall(ctx, client, action, attempt, call)
+		if err == nil {
+			return nil
+		}
+
+		errCode := apiErrorCode(err)
+		if isPermanentCommonError(errCode) || isPermanentCdnError(errCode) {
+			return backoff.Permanent(err)
+		}
+		if cdnNonIdempotentActions[action] && !isRejectedRequestError(errCode) {
+			return backoff.Permanent(err)
+		}
+
+		return err
+	}
+
+	reconnectBackoff := backoff.NewExponentialBackOff()
+	reconnectBackoff.MaxElapsedTime = 30 * time.Second
+	return backoff.Retry(callApi, backoff.WithContext(reconnectBackoff, ctx))
+}
+
+// describeCdnDomain returns the summary of the CDN domain.
+//
+// Parameters:
+//   - ctx: Context.
+//   - client: The CDN client.
+//   - domainName: The name of the CDN domain.
+//
+// Returns:
+//   - domain: The summary of the CDN domain, nil if the domain is not found.
+//   - err: Error of listing the CDN domains.
+func describeCdnDomain(ctx context.Context, client *byteplusCdnClient.CDN, domainName string) (domain *byteplusCdnClient.DomainSummary, err error) {
+	pageNum := int64(1)
+	pageSize := int64(100)
+	listCdnDomainsRequest := &byteplusCdnClient.ListCdnDomainsRequest{
+		Domain:     byteplusCdnClient.GetStrPtr(domainName),
+		ExactMatch: byteplusCdnClient.GetBoolPtr(true),
+		PageNum:    &pageNum,
+		PageSize:   &pageSize,
+	}
+
+	var response *byteplusCdnClient.ListCdnDomainsResponse
+	err = retryCdnApiCall(ctx, client, "ListCdnDomains", func() (err error) {
+		response, err = client.ListCdnDomains(listCdnDomainsRequest)
+		return
+	})
+	if isCdnDomainNotFoundError(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	for _, cdnDomain := range response.Result.Data {
+		if cdnDomain.Domain == domainName {
+			return &cdnDomain, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// listCdnDomains returns the summaries of all the CDN domains that match the
+// filters of the request, the pages are listed until all the domains are
+// returned.
+//
+// Parameters:
+//   - ctx: Context.
+//   - client: The CDN client.
+//   - request: The filters of ListCdnDomains API, the page number and page
+//     size are overridden.
+//
+// Returns:
+//   - domains: The summaries of the CDN domains.
+//   - err: Error of listing the CDN domains.
+func listCdnDomains(ctx context.Context, client *byteplusCdnClient.CDN, request *byteplusCdnClient.ListCdnDomainsRequest) (domains []byteplusCdnClient.DomainSummary, err error) {
+	pageSize := int64(cdnDomainsPageSize)
+	request.PageSize = &pageSize
+
+	for pageNum := int64(1); ; pageNum++ {
+		request.PageNum = byteplusCdnClient.GetInt64Ptr(pageNum)
+
+		var response *byteplusCdnClient.ListCdnDomainsResponse
+		err = retryCdnApiCall(ctx, client, "ListCdnDomains", func() (err error) {
+			response, err = client.ListCdnDomains(request)
+			return
+		})
+		if isCdnDomainNotFoundError(err) {
+			return domains, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		domains = append(domains, response.Result.Data...)
+		if len(response.Result.Data) < cdnDomainsPageSize || int64(len(domains)) >= response.Result.Total {
+			return domains, nil
+		}
+	}
+}
+
+// isCdnDomainNotFoundError returns whether the error is caused by the CDN
+// domain does not exist.
+func isCdnDomainNotFoundError(err error) bool {
+	byteErr, ok := err.(byteplusCdnClient.CDNError)
+	return ok && byteErr.Code == ERR_CODE_NOT_FOUND_DOMAIN
+}
+
+// describeCdnDomainConfig returns the configurations of the CDN domain.
+//
+// Parameters:
+//   - ctx: Context.
+//   - client: The CDN client.
+//   - domainName: The name of the CDN domain.
+//
+// Returns:
+//   - config: The configurations of the CDN domain.
+//   - err: Error of describing the configurations.
+func describeCdnDomainConfig(ctx context.Context, client *byteplusCdnClient.CDN, domainName string) (config *byteplusCdnClient.DomainConfig, err error) {
+	var response *byteplusCdnClient.DescribeCdnConfigResponse
+	err = retryCdnApiCall(ctx, client, "DescribeCdnConfig", func() (err error) {
+		response, err = client.DescribeCdnConfig(&byteplusCdnClient.DescribeCdnConfigRequest{
+			Domain: domainName,
+		})
+		return
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &response.Result.DomainConfig, nil
+}
+
+// updateCdnDomainConfig updates the configurations of the CDN domain, and
+// waits until the domain is online again if it is online.
+//
+// Parameters:
+//   - ctx: Context.
+//   - client: The CDN client.
+//   - request: The configurations to update, Domain must be set.
+//
+// Returns:
+//   - diags: Diagnostics of updating the configurations or waiting for the domain.
+func updateCdnDomainConfig(ctx context.Context, client *byteplusCdnClient.CDN, request *byteplusCdnClient.UpdateCdnConfigRequest) (diags diag.Diagnostics) {
+	domainName := stringValue(request.Domain)
+	cdnDomain, err := describeCdnDomain(ctx, client, domainName)
+	if err != nil {
+		diags.AddError(
+			"[API ERROR] Failed to Describe CDN Domain.",
+			err.Error(),
+		)
+		return
+	}
+	if cdnDomain == nil {
+		diags.AddAttributeError(
+			path.Root("domain_name"),
+			"CDN Domain Not Found",
+			fmt.Sprintf("CDN domain %s is not found.", domainName),
+		)
+		return
+	}
+
+	err = retryCdnApiCall(ctx, client, "UpdateCdnConfig", func() (err error) {
+		_, err = client.UpdateCdnConfig(request)
+		return
+	})
+	if err != nil {
+		diags.AddError(
+			"[API ERROR] Failed to Update CDN Domain Config.",
+			err.Error(),
+		)
+		return
+	}
+
+	if cdnDomain.Status == cdnDomainStatusOnline {
+		_, err = waitForCdnDomainStatus(ctx, client, domainName, cdnDomainStatusOnline, cdnDomainStatusTimeout)
+		if err != nil {
+			diags.AddError(
+				"[API ERROR] Failed to Wait for CDN Domain Online.",
+				err.Error(),
+			)
+			return
+		}
+	}
+
+	return
+}
+
+// waitForCdnDomainStatus polls the CDN domain with backoff until it reaches
+// the status, e.g. a newly added domain becomes online after configuring.
+//
+// Parameters:
+//   - ctx: Context.
+//   - client: The CDN client.
+//   - domainName: The name of the CDN domain.
+//   - status: The status to wait for.
+//   - timeout: The maximum time to wait.
+//
+// Returns:
+//   - domain: The summary of the CDN domain in the status.
+//   - err: Error of listing the CDN domains, the domain failed to be
+//     configured, or does not reach the status before timeout.
+func waitForCdnDomainStatus(ctx context.Context, client *byteplusCdnClient.CDN, domainName, status string, timeout time.Duration) (domain *byteplusCdnClient.DomainSummary, err error) {
+	checkStatus := func() error {
+		domain, err = describeCdnDomain(ctx, client, domainName)
+		if err != nil {
+			return backoff.Permanent(err)
+		}
+		if domain == nil {
+			// The domain may not be listed yet right after it is added.
+			return fmt.Errorf("CDN domain %s is not found, waiting for %s", domainName, status)
+		}
+
+		switch domain.Status {
+		case status:
+			return nil
+		case cdnDomainStatusConfigureFailed, cdnDomainStatusAuditFailed:
+			return backoff.Permanent(fmt.Errorf("CDN domain %s is %s", domainName, domain.Status))
+		default:
+			return fmt.Errorf("CDN domain %s is %s, waiting for %s", domainName, domain.Status, status)
+		}
+	}
+
+	statusBackoff := backoff.NewExponentialBackOff()
+	statusBackoff.MaxInterval = 30 * time.Second
+	statusBackoff.MaxElapsedTime = timeout
+	err = backoff.Retry(checkStatus, backoff.WithContext(statusBackoff, ctx))
+	return
+}
+
+// waitForCdnDomainDeleted polls the CDN domain with backoff until it is no
+// longer listed.
+//
+// Parameters:
+//   - ctx: Context.
+//   - client: The CDN client.
+//   - domainName: The name of the CDN domain.
+//   - timeout: The maximum time to wait.
+//
+// Returns:
+//   - err: Error of listing the CDN domains, or the domain is not deleted
+//     before timeout.
+func waitForCdnDomainDeleted(ctx context.Context, client *byteplusCdnClient.CDN, domainName string, timeout time.Duration) (err error) {
+	checkDeleted := func() error {
+		domain, err := describeCdnDomain(ctx, client, domainName)
+		if err != nil {
+			return backoff.Permanent(err)
+		}
+		if domain != nil {
+			return fmt.Errorf("CDN domain %s is %s, waiting for deletion", domainName, domain.Status)
+		}
+
+		return nil
+	}
+
+	statusBackoff := backoff.NewExponentialBackOff()
+	statusBackoff.MaxInterval = 30 * time.Second
+	statusBackoff.MaxElapsedTime = timeout
+	return backoff.Retry(checkDeleted, backoff.WithContext(statusBackoff, ctx))
+}
+
+// cdnResourceTags converts the tags to the resource tags of CDN API, sorted by
+// key.
+func cdnResourceTags(tags map[string]string) []byteplusCdnClient.ResourceTag {
+	keys := make([]string, 0, len(tags))
+	for key := range tags {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	resourceTags := make([]byteplusCdnClient.ResourceTag, 0, len(keys))
+	for _, key := range keys {
+		resourceTags = append(resourceTags, byteplusCdnClient.ResourceTag{
+			Key:   byteplusCdnClient.GetStrPtr(key),
+			Value: byteplusCdnClient.GetStrPtr(tags[key]),
+		})
+	}
+
+	return resourceTags
+}
+
+// cdnTagsMap converts the resource tags of CDN API to a map.
+func cdnTagsMap(resourceTags []byteplusCdnClient.ResourceTag) map[string]string {
+	tags := make(map[string]string, len(resourceTags))
+	for _, resourceTag := range resourceTags {
+		if resourceTag.Key == nil {
+			continue
+		}
+
+		value := ""
+		if resourceTag.Value != nil {
+			value = *resourceTag.Value
+		}
+		tags[*resourceTag.Key] = value
+	}
+
+	return tags
+}
+
+// updateCdnDomainTags changes the tags of the CDN domain from the old tags to
+// the new tags.
+//
+// Parameters:
+//   - ctx: Context.
+//   - client: The CDN client.
+//   - domainName: The name of the CDN domain.
+//   - oldTags: The current tags of the CDN domain.
+//   - newTags: The desired tags of the CDN domain.
+//
+// Returns:
+//   - err: Error of tagging or untagging the CDN domain.
+func updateCdnDomainTags(ctx context.Context, client *byteplusCdnClient.CDN, domainName string, oldTags, newTags map[string]string) (err error) {
+	updatedTags, removedKeys := diffTags(oldTags, newTags)
+
+	if len(removedKeys) > 0 {
+		err = retryCdnApiCall(ctx, client, "UntagResources", func() (err error) {
+			_, err = client.UntagResources(&byteplusCdnClient.UntagResourcesRequest{
+				ResourceIds:  []string{domainName},
+				ResourceType: cdnResourceTypeDomain,
+				TagKeys:      removedKeys,
+			})
+			return
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	if len(updatedTags) > 0 {
+		err = retryCdnApiCall(ctx, client, "TagResources", func() (err error) {
+			_, err = client.TagResources(&byteplusCdnClient.TagResourcesRequest{
+				ResourceIds:  []string{domainName},
+				ResourceType: cdnResourceTypeDomain,
+				Tags:         cdnResourceTags(updatedTags),
+			})
+			return
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
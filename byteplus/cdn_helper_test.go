@@ -0,0 +1,49 @@
+package byteplus
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	byteplusBaseClient "github.com/byteplus-sdk/byteplus-sdk-golang/base"
+	byteplusCdnClient "github.com/byteplus-sdk/byteplus-sdk-golang/service/cdn"
+)
+
+func TestRetryCdnApiCall(t *testing.T) {
+	networkErr := errors.New("read: connection reset by peer")
+	throttledErr := byteplusCdnClient.CDNError{Code: ERR_SERVICE_ACCESS_KEY_LIMIT_EXCEEDED}
+
+	tests := []struct {
+		name      string
+		action    string
+		err       error
+		wantCalls int
+	}{
+		{name: "describe after network error", action: "DescribeCdnConfig", err: networkErr, wantCalls: 2},
+		{name: "submit after network error", action: "SubmitRefreshTask", err: networkErr, wantCalls: 1},
+		{name: "add domain after network error", action: "AddCdnDomain", err: networkErr, wantCalls: 1},
+		{name: "submit after throttled", action: "SubmitPreloadTask", err: throttledErr, wantCalls: 2},
+		{name: "describe after permanent error", action: "DescribeCdnConfig", err: byteplusCdnClient.CDNError{Code: ERR_CODE_NOT_FOUND_DOMAIN}, wantCalls: 1},
+	}
+
+	client := newCdnClient(byteplusBaseClient.Credentials{}, "", nil)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := 0
+			err := retryCdnApiCall(context.Background(), client, tt.action, func() error {
+				calls++
+				if calls == 1 {
+					return tt.err
+				}
+				return nil
+			})
+
+			if calls != tt.wantCalls {
+				t.Errorf("calls = %d, want %d", calls, tt.wantCalls)
+			}
+			if wantErr := tt.wantCalls == 1; (err != nil) != wantErr {
+				t.Errorf("error = %v, want error %v", err, wantErr)
+			}
+		})
+	}
+}
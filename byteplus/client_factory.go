@@ -0,0 +1,403 @@
+package byteplus
+
+import (
+	"context"
+	"net/http"
+	"sync"
+
+	"github.com/byteplus-sdk/byteplus-go-sdk-v2/byteplus/credentials"
+	byteplusIamClient "github.com/byteplus-sdk/byteplus-go-sdk-v2/service/iam"
+	byteplusBaseClient "github.com/byteplus-sdk/byteplus-sdk-golang/base"
+	byteplusCdnClient "github.com/byteplus-sdk/byteplus-sdk-golang/service/cdn"
+	byteplusStsClient "github.com/byteplus-sdk/byteplus-sdk-golang/service/sts"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+)
+
+// clientCacheKey identifies the clients created from the same client
+// configurations, rather than the credentials they resolve to, so temporary
+// credentials renewed by STS or a credential process replace the cached
+// client of the same identity instead of adding a new one.
+type clientCacheKey struct {
+	// The access key in client_config, empty for the credentials of the
+	// provider.
+	accessKey       string
+	region          string
+	roleTrn         string
+	sessionName     string
+	durationSeconds int64
+	policy          string
+}
+
+// cachedIamClient is the IAM client with the credentials it is created with.
+type cachedIamClient struct {
+	credentials byteplusBaseClient.Credentials
+	client      *byteplusIamClient.IAM
+}
+
+// cachedAssumeRoleCredentials is the credentials of the assumed role with
+// the source credentials of client_config, the source credentials are empty
+// if the role is assumed with the credentials of the provider.
+type cachedAssumeRoleCredentials struct {
+	source      byteplusBaseClient.Credentials
+	credentials *credentials.Credentials
+}
+
+// clientFactory creates the Byteplus API clients of both SDKs from the
+// provider configurations and the optional client_config block of resources
+// and data sources.
+//
+// The clients are created once for each client configuration and reused
+// across all resources and data sources, a client is replaced once its
+// credentials are renewed. The clients are never modified after creation, so
+// they are safe to be used concurrently, and the clients created from
+// client_config never leak into the reads of others.
+type clientFactory struct {
+	mu sync.Mutex
+
+	// The credentials of the provider, temporary credentials are renewed
+	// before they expire.
+	credentials *credentials.Credentials
+
+	// The TRN of the role assumed by the provider, empty if no role is
+	// assumed.
+	roleTrn string
+
+	// The region configured in the provider.
+	region string
+
+	// The custom endpoints of the clients, the clients created from
+	// client_config use the same endpoints.
+	endpoints serviceEndpoints
+
+	// The HTTP client with the proxy, TLS and timeout settings, the clients
+	// created from client_config use the same HTTP client.
+	httpClient *http.Client
+
+	// The IAM client of the provider. Unlike the CDN client, it holds the
+	// provider credentials instead of a copy, so it never has to be renewed.
+	providerIamClient *byteplusIamClient.IAM
+
+	cdnClients            map[clientCacheKey]*byteplusCdnClient.CDN
+	iamClients            map[clientCacheKey]*cachedIamClient
+	assumeRoleCredentials map[clientCacheKey]*cachedAssumeRoleCredentials
+}
+
+// newClientFactory creates the client factory of the provider.
+//
+// Parameters:
+//   - providerCredentials: The credentials of the provider.
+//   - roleTrn: The TRN of the role assumed by the provider, empty if no role is assumed.
+//   - region: The region configured in the provider.
+//   - endpoints: The custom endpoints of Byteplus services.
+//   - httpClient: The HTTP client with the proxy, TLS and timeout settings.
+//
+// Returns:
+//   - factory: The client factory.
+//   - err: Error of creating the IAM client of the provider.
+func newClientFactory(providerCredentials *credentials.Credentials, roleTrn, region string, endpoints serviceEndpoints, httpClient *http.Client) (factory *clientFactory, err error) {
+	providerIamClient, err := newIamClient(providerCredentials, region, endpoints.iam, httpClient)
+	if err != nil {
+		return nil, err
+	}
+
+	return &clientFactory{
+		credentials:           providerCredentials,
+		roleTrn:               roleTrn,
+		region:                region,
+		endpoints:             endpoints,
+		httpClient:            httpClient,
+		providerIamClient:     providerIamClient,
+		cdnClients:            make(map[clientCacheKey]*byteplusCdnClient.CDN),
+		iamClients:            make(map[clientCacheKey]*cachedIamClient),
+		assumeRoleCredentials: make(map[clientCacheKey]*cachedAssumeRoleCredentials),
+	}, nil
+}
+
+// newClientCacheKey returns the cache key of the clients created from the
+// client_config block in the region.
+func newClientCacheKey(config *clientConfig, region string) clientCacheKey {
+	key := clientCacheKey{
+		region: region,
+	}
+	if !isClientConfigSet(config) {
+		return key
+	}
+
+	key.accessKey = config.AccessKey.ValueString()
+	if config.AssumeRole != nil {
+		key.roleTrn = config.AssumeRole.RoleTrn.ValueString()
+		key.sessionName = config.AssumeRole.SessionName.ValueString()
+		key.durationSeconds = config.AssumeRole.DurationSeconds.ValueInt64()
+		key.policy = config.AssumeRole.Policy.ValueString()
+	}
+
+	return key
+}
+
+// isSameCredentials returns whether the credentials are the same, ignoring
+// the service.
+func isSameCredentials(a, b byteplusBaseClient.Credentials) bool {
+	return a.AccessKeyID == b.AccessKeyID &&
+		a.SecretAccessKey == b.SecretAccessKey &&
+		a.SessionToken == b.SessionToken &&
+		a.Region == b.Region
+}
+
+// isClientConfigSet returns whether any configuration in the client_config
+// block is set.
+func isClientConfigSet(config *clientConfig) bool {
+	if config == nil {
+		return false
+	}
+
+	return config.Region.ValueString() != "" ||
+		config.AccessKey.ValueString() != "" ||
+		config.SecretKey.ValueString() != "" ||
+		config.SessionToken.ValueString() != "" ||
+		config.AssumeRole != nil
+}
+
+// cdnClient returns the CDN client of the client_config block, or the CDN
+// client of the provider if the block is not set.
+//
+// Parameters:
+//   - config: The client_config block, nil if not set.
+//
+// Returns:
+//   - client: The CDN client.
+//   - diags: Diagnostics of resolving the credentials of the client.
+func (f *clientFactory) cdnClient(config *clientConfig) (client *byteplusCdnClient.CDN, diags diag.Diagnostics) {
+	clientCredentials, diags := f.clientCredentials(config, byteplusCdnClient.ServiceName)
+	if diags.HasError() {
+		return
+	}
+
+	key := newClientCacheKey(config, clientCredentials.Region)
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	// The cached client is replaced once the credentials are renewed.
+	if client, ok := f.cdnClients[key]; ok && isSameCredentials(client.Client.ServiceInfo.Credentials, clientCredentials) {
+		return client, diags
+	}
+
+	client = newCdnClient(clientCredentials, f.endpoints.cdn, f.httpClient)
+	f.cdnClients[key] = client
+	return client, diags
+}
+
+// iamClient returns the IAM client of the client_config block, or the IAM
+// client of the provider if the block is not set.
+//
+// Parameters:
+//   - config: The client_config block, nil if not set.
+//
+// Returns:
+//   - client: The IAM client.
+//   - diags: Diagnostics of resolving the credentials or creating the client.
+func (f *clientFactory) iamClient(config *clientConfig) (client *byteplusIamClient.IAM, diags diag.Diagnostics) {
+	if !isClientConfigSet(config) {
+		return f.providerIamClient, nil
+	}
+
+	clientCredentials, diags := f.clientCredentials(config, byteplusIamClient.ServiceName)
+	if diags.HasError() {
+		return
+	}
+
+	key := newClientCacheKey(config, clientCredentials.Region)
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	// The cached client is replaced once the credentials are renewed.
+	if cached, ok := f.iamClients[key]; ok && isSameCredentials(cached.credentials, clientCredentials) {
+		return cached.client, diags
+	}
+
+	client, err := newIamClient(
+		credentials.NewStaticCredentials(
+			clientCredentials.AccessKeyID,
+			clientCredentials.SecretAccessKey,
+			clientCredentials.SessionToken,
+		),
+		clientCredentials.Region,
+		f.endpoints.iam,
+		f.httpClient,
+	)
+	if err != nil {
+		diags.AddError(
+			"Unable to Create Byteplus IAM API Client",
+			"An unexpected error occurred when creating the Byteplus IAM API client "+
+				"from client_config.\n\n"+
+				"BytePlus IAM Client Error: "+err.Error(),
+		)
+		return
+	}
+
+	f.iamClients[key] = &cachedIamClient{
+		credentials: clientCredentials,
+		client:      client,
+	}
+	return client, diags
+}
+
+// clientCredentials resolves the credentials of a client. Each configuration
+// in the client_config block overrides the one of the provider:
+//
+//   - region overrides the region of the provider.
+//   - access_key and secret_key must be set together, and override the
+//     credentials of the provider.
+//   - session_token is only valid together with access_key and secret_key,
+//     the session token of the provider is only used with the credentials of
+//     the provider.
+//   - assume_role assumes the role with the credentials above.
+//
+// Parameters:
+//   - config: The client_config block, nil if not set.
+//   - service: The name of the Byteplus service of the client.
+//
+// Returns:
+//   - clientCredentials: The credentials and region of the client.
+//   - diags: Diagnostics of the invalid configurations or retrieving the credentials.
+func (f *clientFactory) clientCredentials(config *clientConfig, service string) (clientCredentials byteplusBaseClient.Credentials, diags diag.Diagnostics) {
+	providerCredentials, err := f.credentials.GetBase(f.region, service)
+	if err != nil {
+		diags.AddError(
+			"Unable to Retrieve Byteplus Credentials",
+			"The credentials of the provider cannot be retrieved.\n\n"+err.Error(),
+		)
+		return
+	}
+
+	if !isClientConfigSet(config) {
+		return providerCredentials, nil
+	}
+
+	configPath := path.Root("client_config")
+	if config.Region.IsUnknown() || config.AccessKey.IsUnknown() ||
+		config.SecretKey.IsUnknown() || config.SessionToken.IsUnknown() {
+		diags.AddAttributeError(
+			configPath,
+			"Unknown Byteplus client configuration",
+			"The client_config block must be known before the Byteplus API client can be created.",
+		)
+		return
+	}
+
+	clientCredentials = providerCredentials
+	if region := config.Region.ValueString(); region != "" {
+		clientCredentials.Region = region
+	}
+
+	accessKey := config.AccessKey.ValueString()
+	secretKey := config.SecretKey.ValueString()
+	sessionToken := config.SessionToken.ValueString()
+	switch {
+	case accessKey != "" && secretKey != "":
+		clientCredentials.AccessKeyID = accessKey
+		clientCredentials.SecretAccessKey = secretKey
+		clientCredentials.SessionToken = sessionToken
+	case accessKey != "":
+		diags.AddAttributeError(
+			configPath.AtName("secret_key"),
+			"Missing Byteplus secret key",
+			"The secret_key must be set together with access_key in client_config.",
+		)
+	case secretKey != "":
+		diags.AddAttributeError(
+			configPath.AtName("access_key"),
+			"Missing Byteplus access key",
+			"The access_key must be set together with secret_key in client_config.",
+		)
+	case sessionToken != "":
+		diags.AddAttributeError(
+			configPath.AtName("session_token"),
+			"Invalid Byteplus session token",
+			"The session_token must be set together with access_key and secret_key in client_config.",
+		)
+	}
+	if diags.HasError() {
+		return
+	}
+
+	if config.AssumeRole != nil {
+		assumeRolePath := configPath.AtName("assume_role")
+		diags.Append(validateAssumeRoleConfig(config.AssumeRole, assumeRolePath)...)
+		if diags.HasError() {
+			return
+		}
+
+		value, err := f.assumeRole(config, clientCredentials).Get()
+		if err != nil {
+			diags.AddAttributeError(
+				assumeRolePath,
+				"Unable to Assume Byteplus Role",
+				"The role in client_config cannot be assumed.\n\n"+err.Error(),
+			)
+			return
+		}
+
+		clientCredentials.AccessKeyID = value.AccessKeyID
+		clientCredentials.SecretAccessKey = value.SecretAccessKey
+		clientCredentials.SessionToken = value.SessionToken
+	}
+
+	return clientCredentials, diags
+}
+
+// assumeRole returns the credentials of the role assumed with the source
+// credentials. The credentials are reused until they expire, so the role is
+// not assumed again for every client.
+//
+// Parameters:
+//   - config: The client_config block with the assume role configurations.
+//   - source: The resolved credentials and region of client_config.
+//
+// Returns:
+//   - The expirable credentials of the assumed role.
+func (f *clientFactory) assumeRole(config *clientConfig, source byteplusBaseClient.Credentials) *credentials.Credentials {
+	key := newClientCacheKey(config, source.Region)
+
+	// The role assumed with the credentials of the provider retrieves the
+	// renewed credentials of the provider by itself.
+	var cachedSource byteplusBaseClient.Credentials
+	sourceCredentials := f.credentials
+	if config.AccessKey.ValueString() != "" {
+		cachedSource = source
+		sourceCredentials = credentials.NewStaticCredentials(source.AccessKeyID, source.SecretAccessKey, source.SessionToken)
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if cached, ok := f.assumeRoleCredentials[key]; ok && isSameCredentials(cached.source, cachedSource) {
+		return cached.credentials
+	}
+
+	assumeRoleCredentials := newAssumeRoleCredentials(sourceCredentials, source.Region, f.endpoints.sts, f.httpClient, config.AssumeRole)
+	f.assumeRoleCredentials[key] = &cachedAssumeRoleCredentials{
+		source:      cachedSource,
+		credentials: assumeRoleCredentials,
+	}
+	return assumeRoleCredentials
+}
+
+// callerIdentity returns the identity of the credentials of the provider.
+//
+// Parameters:
+//   - ctx: Context.
+//
+// Returns:
+//   - identity: The identity of the credentials.
+//   - err: Error of retrieving the credentials or calling STS API.
+func (f *clientFactory) callerIdentity(ctx context.Context) (identity *callerIdentity, err error) {
+	providerCredentials, err := f.credentials.GetBase(f.region, byteplusStsClient.ServiceName)
+	if err != nil {
+		return nil, err
+	}
+
+	return getCallerIdentity(ctx, newStsClient(providerCredentials, f.endpoints.sts, f.httpClient), f.roleTrn)
+}
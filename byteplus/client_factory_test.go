@@ -0,0 +1,75 @@
+package byteplus
+
+import (
+	"testing"
+
+	"github.com/byteplus-sdk/byteplus-go-sdk-v2/byteplus/credentials"
+	byteplusCdnClient "github.com/byteplus-sdk/byteplus-sdk-golang/service/cdn"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// rotatingProvider returns new temporary credentials every time it is
+// retrieved, like the credentials renewed by STS.
+type rotatingProvider struct {
+	retrieved int
+}
+
+func (p *rotatingProvider) Retrieve() (credentials.Value, error) {
+	p.retrieved++
+	return credentials.Value{
+		AccessKeyID:     "AKTEST",
+		SecretAccessKey: "SKTEST",
+		SessionToken:    string(rune('a' + p.retrieved)),
+	}, nil
+}
+
+func (p *rotatingProvider) IsExpired() bool {
+	return true
+}
+
+func TestClientFactoryCdnClientCache(t *testing.T) {
+	provider := &rotatingProvider{}
+	factory, err := newClientFactory(credentials.NewCredentials(provider), "", "ap-singapore-1", serviceEndpoints{}, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	otherKeys := &clientConfig{
+		AccessKey: types.StringValue("AKOTHER"),
+		SecretKey: types.StringValue("SKOTHER"),
+	}
+	otherRegion := &clientConfig{
+		Region: types.StringValue("ap-southeast-1"),
+	}
+
+	tests := []struct {
+		name       string
+		config     *clientConfig
+		wantTotal  int
+		wantReused bool
+	}{
+		{name: "provider", config: nil, wantTotal: 1},
+		{name: "provider with renewed credentials", config: nil, wantTotal: 1},
+		{name: "client_config keys", config: otherKeys, wantTotal: 2},
+		{name: "same client_config keys", config: otherKeys, wantTotal: 2, wantReused: true},
+		{name: "client_config region", config: otherRegion, wantTotal: 3},
+	}
+
+	previous := make(map[*clientConfig]*byteplusCdnClient.CDN)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, diags := factory.cdnClient(tt.config)
+			if diags.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", diags)
+			}
+
+			if total := len(factory.cdnClients); total != tt.wantTotal {
+				t.Errorf("cached clients = %d, want %d", total, tt.wantTotal)
+			}
+			if reused := previous[tt.config] == client; reused != tt.wantReused {
+				t.Errorf("reused = %v, want %v", reused, tt.wantReused)
+			}
+			previous[tt.config] = client
+		})
+	}
+}
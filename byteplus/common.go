@@ -1,9 +1,243 @@
 package byteplus
 
-import "github.com/hashicorp/terraform-plugin-framework/types"
+import (
+	"context"
+
+	datasourceSchema "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	resourceSchema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
 
 type clientConfig struct {
-	Region    types.String `tfsdk:"region"`
-	AccessKey types.String `tfsdk:"access_key"`
-	SecretKey types.String `tfsdk:"secret_key"`
+	Region       types.String      `tfsdk:"region"`
+	AccessKey    types.String      `tfsdk:"access_key"`
+	SecretKey    types.String      `tfsdk:"secret_key"`
+	SessionToken types.String      `tfsdk:"session_token"`
+	AssumeRole   *assumeRoleConfig `tfsdk:"assume_role"`
+}
+
+// dataSourceClientConfigBlock returns the schema of the client_config block
+// of data sources.
+//
+// Parameters:
+//   - region: What the region is of, e.g. "CDN domains".
+//   - permission: What the credentials must have permissions to do, e.g. "list CDN domains".
+//
+// Returns:
+//   - The schema of the client_config block.
+func dataSourceClientConfigBlock(region, permission string) datasourceSchema.SingleNestedBlock {
+	return datasourceSchema.SingleNestedBlock{
+		Description: "Config to override default client created in Provider. " +
+			"This block will not be recorded in state file.",
+		Attributes: map[string]datasourceSchema.Attribute{
+			"region": datasourceSchema.StringAttribute{
+				Description: "The region of the " + region + ". Default to " +
+					"use region configured in the provider.",
+				Optional: true,
+			},
+			"access_key": datasourceSchema.StringAttribute{
+				Description: "The access key that have permissions to " + permission + ". " +
+					"Default to use access key configured in the provider. Must be set " +
+					"together with `secret_key`.",
+				Optional:  true,
+				Sensitive: true,
+			},
+			"secret_key": datasourceSchema.StringAttribute{
+				Description: "The secret key that have permissions to " + permission + ". " +
+					"Default to use secret key configured in the provider. Must be set " +
+					"together with `access_key`.",
+				Optional:  true,
+				Sensitive: true,
+			},
+			"session_token": datasourceSchema.StringAttribute{
+				Description: "The session token of the temporary credentials above. " +
+					"Default to use session token configured in the provider when " +
+					"the access key and secret key are not set.",
+				Optional:  true,
+				Sensitive: true,
+			},
+		},
+		Blocks: map[string]datasourceSchema.Block{
+			"assume_role": datasourceSchema.SingleNestedBlock{
+				Description: "Assume a role through STS with the credentials above to " + permission + ".",
+				Attributes: map[string]datasourceSchema.Attribute{
+					"role_trn": datasourceSchema.StringAttribute{
+						Description: "The TRN of the role to assume, e.g. " +
+							"trn:iam::2100000000:role/terraform.",
+						Optional: true,
+					},
+					"session_name": datasourceSchema.StringAttribute{
+						Description: "The session name of the assumed role. Default to " +
+							"`terraform-provider-st-byteplus`.",
+						Optional: true,
+					},
+					"duration_seconds": datasourceSchema.Int64Attribute{
+						Description: "The duration of the assumed role session in " +
+							"seconds, between 900 and 43200. Default to 3600.",
+						Optional: true,
+					},
+					"policy": datasourceSchema.StringAttribute{
+						Description: "The policy in JSON to further restrict the " +
+							"permissions of the assumed role session.",
+						Optional: true,
+					},
+				},
+			},
+		},
+	}
+}
+
+// resourceClientConfig is the client_config block of resources. The keys are
+// write-only, they are never recorded in state file, and Terraform only sends
+// them to plan, create and update the resource. The resource is read,
+// imported and deleted with the credentials of the provider instead, with the
+// role of assume_role assumed with them.
+type resourceClientConfig struct {
+	Region       types.String      `tfsdk:"region"`
+	AccessKey    types.String      `tfsdk:"access_key"`
+	SecretKey    types.String      `tfsdk:"secret_key"`
+	SessionToken types.String      `tfsdk:"session_token"`
+	AssumeRole   *assumeRoleConfig `tfsdk:"assume_role"`
+}
+
+// setWriteOnlyKeys sets the keys of the block from the configuration, as the
+// write-only keys are always null in the plan.
+//
+// Parameters:
+//   - ctx: Context.
+//   - config: The configuration of the resource.
+//
+// Returns:
+//   - diags: Diagnostics of reading the configuration.
+func (c *resourceClientConfig) setWriteOnlyKeys(ctx context.Context, config tfsdk.Config) (diags diag.Diagnostics) {
+	if c == nil {
+		return
+	}
+
+	var configClientConfig *resourceClientConfig
+	diags.Append(config.GetAttribute(ctx, path.Root("client_config"), &configClientConfig)...)
+	if diags.HasError() || configClientConfig == nil {
+		return
+	}
+
+	c.AccessKey = configClientConfig.AccessKey
+	c.SecretKey = configClientConfig.SecretKey
+	c.SessionToken = configClientConfig.SessionToken
+	return
+}
+
+// toClientConfig returns the client configurations of the block, nil if the
+// block is not set.
+func (c *resourceClientConfig) toClientConfig() *clientConfig {
+	if c == nil {
+		return nil
+	}
+
+	return &clientConfig{
+		Region:       c.Region,
+		AccessKey:    c.AccessKey,
+		SecretKey:    c.SecretKey,
+		SessionToken: c.SessionToken,
+		AssumeRole:   c.AssumeRole,
+	}
+}
+
+// resourceClientConfigBlock returns the schema of the client_config block of
+// resources.
+//
+// Parameters:
+//   - region: What the region is of, e.g. "CDN domain".
+//   - permission: What the credentials must have permissions to do, e.g. "manage CDN domains".
+//
+// Returns:
+//   - The schema of the client_config block.
+func resourceClientConfigBlock(region, permission string) resourceSchema.SingleNestedBlock {
+	return resourceSchema.SingleNestedBlock{
+		Description: "Config to override default client created in Provider. The keys are " +
+			"write-only and not recorded in state file, so they are only used to create and " +
+			"update the resource, which is read, imported and deleted with the credentials " +
+			"of the provider.",
+		Attributes: map[string]resourceSchema.Attribute{
+			"region": resourceSchema.StringAttribute{
+				Description: "The region of the " + region + ". Default to " +
+					"use region configured in the provider.",
+				Optional: true,
+			},
+			"access_key": resourceSchema.StringAttribute{
+				Description: "The access key that have permissions to " + permission + ". " +
+					"Default to use access key configured in the provider. Must be set " +
+					"together with `secret_key`.",
+				Optional:  true,
+				Sensitive: true,
+				WriteOnly: true,
+			},
+			"secret_key": resourceSchema.StringAttribute{
+				Description: "The secret key that have permissions to " + permission + ". " +
+					"Default to use secret key configured in the provider. Must be set " +
+					"together with `access_key`.",
+				Optional:  true,
+				Sensitive: true,
+				WriteOnly: true,
+			},
+			"session_token": resourceSchema.StringAttribute{
+				Description: "The session token of the temporary credentials above. " +
+					"Default to use session token configured in the provider when " +
+					"the access key and secret key are not set.",
+				Optional:  true,
+				Sensitive: true,
+				WriteOnly: true,
+			},
+		},
+		Blocks: map[string]resourceSchema.Block{
+			"assume_role": resourceSchema.SingleNestedBlock{
+				Description: "Assume a role through STS with the credentials above to " + permission + ".",
+				Attributes: map[string]resourceSchema.Attribute{
+					"role_trn": resourceSchema.StringAttribute{
+						Description: "The TRN of the role to assume, e.g. " +
+							"trn:iam::2100000000:role/terraform.",
+						Optional: true,
+					},
+					"session_name": resourceSchema.StringAttribute{
+						Description: "The session name of the assumed role. Default to " +
+							"`terraform-provider-st-byteplus`.",
+						Optional: true,
+					},
+					"duration_seconds": resourceSchema.Int64Attribute{
+						Description: "The duration of the assumed role session in " +
+							"seconds, between 900 and 43200. Default to 3600.",
+						Optional: true,
+					},
+					"policy": resourceSchema.StringAttribute{
+						Description: "The policy in JSON to further restrict the " +
+							"permissions of the assumed role session.",
+						Optional: true,
+					},
+				},
+			},
+		},
+	}
+}
+
+type endpointsConfig struct {
+	Cdn types.String `tfsdk:"cdn"`
+	Iam types.String `tfsdk:"iam"`
+	Sts types.String `tfsdk:"sts"`
+}
+
+// serviceEndpoints are the custom endpoints of Byteplus services, empty
+// endpoint means the public endpoint of the service.
+type serviceEndpoints struct {
+	cdn string
+	iam string
+	sts string
+}
+
+type assumeRoleConfig struct {
+	RoleTrn         types.String `tfsdk:"role_trn"`
+	SessionName     types.String `tfsdk:"session_name"`
+	DurationSeconds types.Int64  `tfsdk:"duration_seconds"`
+	Policy          types.String `tfsdk:"policy"`
 }
@@ -47,6 +47,24 @@ func isPermanentCommonError(errCode string) bool {
 	}
 }
 
+// isCredentialsError returns whether the error is caused by invalid, revoked
+// or expired credentials.
+func isCredentialsError(errCode string) bool {
+	switch errCode {
+	case
+		ERR_CODE_MISSING_AUTHENTICATION_TOKEN,
+		ERR_CODE_MISSING_SIGNATURE,
+		ERR_CODE_INVALID_TIMESTAMP,
+		ERR_CODE_INVALID_ACCESS_KEY,
+		ERR_CODE_SIGNATURE_DOES_NOT_MATCH,
+		ERR_CODE_INVALID_AUTHORIZATION,
+		ERR_CODE_INVALID_CREDENTIAL:
+		return true
+	default:
+		return false
+	}
+}
+
 func isAbleToRetry(errCode string) bool {
 	switch errCode {
 	case
@@ -58,3 +76,16 @@ func isAbleToRetry(errCode string) bool {
 	}
 	// return false
 }
+
+// isRejectedRequestError returns whether the request is rejected before it is
+// processed, so it is safe to be sent again even if it is not idempotent.
+func isRejectedRequestError(errCode string) bool {
+	switch errCode {
+	case
+		ERR_SERVICE_ACCESS_KEY_LIMIT_EXCEEDED,
+		ERR_CODE_SERVICE_UNAVAILABLE_TEMP:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,471 @@
+package byteplus
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/byteplus-sdk/byteplus-go-sdk-v2/byteplus/bytepluserr"
+	"github.com/byteplus-sdk/byteplus-go-sdk-v2/byteplus/credentials"
+	byteplusBaseClient "github.com/byteplus-sdk/byteplus-sdk-golang/base"
+	byteplusStsClient "github.com/byteplus-sdk/byteplus-sdk-golang/service/sts"
+	"github.com/cenkalti/backoff/v4"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+)
+
+const (
+	assumeRoleProviderName            = "AssumeRoleProvider"
+	defaultAssumeRoleSessionName      = "terraform-provider-st-byteplus"
+	defaultAssumeRoleDuration         = 3600
+	minAssumeRoleDuration             = 900
+	maxAssumeRoleDuration             = 43200
+	assumeRoleCredentialsExpiryWindow = 5 * time.Minute
+
+	processCredentialsExpiryWindow = 5 * time.Minute
+
+	defaultSharedCredentialsProfile = "default"
+
+	sharedCredentialsAccessKey    = "byteplus_access_key_id"
+	sharedCredentialsSecretKey    = "byteplus_secret_access_key"
+	sharedCredentialsSessionToken = "byteplus_session_token"
+	sharedCredentialsProcess      = "credential_process"
+	sharedCredentialsRegion       = "region"
+)
+
+// sharedCredentialsProfile is a profile loaded from a shared credentials file.
+type sharedCredentialsProfile struct {
+	Name              string
+	Filename          string
+	AccessKey         string
+	SecretKey         string
+	SessionToken      string
+	CredentialProcess string
+	Region            string
+}
+
+// defaultSharedCredentialsFiles returns the shared credentials files to be
+// used when none is configured in the provider, which is the file set in
+// BYTEPLUS_SHARED_CREDENTIALS_FILE or $HOME/.byteplus/credentials.
+func defaultSharedCredentialsFiles() []string {
+	if filename := os.Getenv("BYTEPLUS_SHARED_CREDENTIALS_FILE"); filename != "" {
+		return []string{filename}
+	}
+
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return nil
+	}
+
+	return []string{filepath.Join(homeDir, ".byteplus", "credentials")}
+}
+
+// loadSharedCredentialsProfile looks up the profile in the shared credentials
+// files, the first file that contains the profile wins.
+//
+// Parameters:
+//   - filenames: List of shared credentials files, in order of precedence.
+//   - profile: The profile name.
+//
+// Returns:
+//   - sharedProfile: The loaded profile, return nil if the profile is not found in any of the files.
+//   - err: Error of reading or parsing the files.
+func loadSharedCredentialsProfile(filenames []string, profile string) (sharedProfile *sharedCredentialsProfile, err error) {
+	for _, filename := range filenames {
+		filename, err = expandHomeDir(filename)
+		if err != nil {
+			return nil, err
+		}
+
+		values, found, err := readSharedCredentialsFile(filename, profile)
+		if err != nil {
+			return nil, err
+		}
+		if !found {
+			continue
+		}
+
+		return &sharedCredentialsProfile{
+			Name:              profile,
+			Filename:          filename,
+			AccessKey:         values[sharedCredentialsAccessKey],
+			SecretKey:         values[sharedCredentialsSecretKey],
+			SessionToken:      values[sharedCredentialsSessionToken],
+			CredentialProcess: values[sharedCredentialsProcess],
+			Region:            values[sharedCredentialsRegion],
+		}, nil
+	}
+
+	return nil, nil
+}
+
+// readSharedCredentialsFile reads the key values of a profile from an INI
+// formatted shared credentials file. Both "[name]" and "[profile name]"
+// section headers are accepted, so credentials and config style files can be
+// used. A file that does not exist is treated as not containing the profile.
+//
+// Parameters:
+//   - filename: Path of the shared credentials file.
+//   - profile: The profile name.
+//
+// Returns:
+//   - values: Key values of the profile.
+//   - found: Whether the profile exists in the file.
+//   - err: Error of reading the file.
+func readSharedCredentialsFile(filename, profile string) (values map[string]string, found bool, err error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, false, nil
+		}
+		return nil, false, fmt.Errorf("failed to open shared credentials file %s: %w", filename, err)
+	}
+	defer file.Close()
+
+	values = make(map[string]string)
+	inProfile := false
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, ";") {
+			continue
+		}
+
+		if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
+			section := strings.TrimSpace(strings.Trim(line, "[]"))
+			section = strings.TrimSpace(strings.TrimPrefix(section, "profile "))
+			inProfile = section == profile
+			found = found || inProfile
+			continue
+		}
+
+		if !inProfile {
+			continue
+		}
+
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			continue
+		}
+		values[strings.TrimSpace(key)] = strings.TrimSpace(value)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, false, fmt.Errorf("failed to read shared credentials file %s: %w", filename, err)
+	}
+
+	return values, found, nil
+}
+
+// expandHomeDir replaces the leading "~" of the path with the home directory
+// of the current user.
+func expandHomeDir(path string) (string, error) {
+	if path != "~" && !strings.HasPrefix(path, "~/") {
+		return path, nil
+	}
+
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to expand home directory in %s: %w", path, err)
+	}
+
+	return filepath.Join(homeDir, strings.TrimPrefix(path, "~")), nil
+}
+
+// assumeRoleProvider retrieves temporary credentials by assuming a role
+// through STS AssumeRole with the source credentials. The source credentials
+// are retrieved again before each AssumeRole, so the role can still be
+// assumed after the source credentials are renewed, and their session token
+// is sent along, so temporary credentials, e.g. of a credential process or
+// another assumed role, can assume the role too. The credentials are renewed
+// by credentials.Credentials before they expire, so long running applies
+// will not fail halfway.
+type assumeRoleProvider struct {
+	credentials.Expiry
+
+	source          *credentials.Credentials
+	region          string
+	endpoint        string
+	httpClient      *http.Client
+	roleTrn         string
+	sessionName     string
+	durationSeconds int
+	policy          string
+}
+
+// newAssumeRoleCredentials returns the credentials of the role assumed with
+// the source credentials.
+//
+// Parameters:
+//   - source: The credentials used to call STS AssumeRole.
+//   - region: The region of STS API.
+//   - endpoint: The custom endpoint of STS API, empty to use the public endpoint.
+//   - httpClient: The HTTP client with the proxy, TLS and timeout settings, nil to use the default.
+//   - config: The validated assume role configurations.
+//
+// Returns:
+//   - The expirable credentials of the assumed role.
+func newAssumeRoleCredentials(source *credentials.Credentials, region, endpoint string, httpClient *http.Client, config *assumeRoleConfig) *credentials.Credentials {
+	provider := &assumeRoleProvider{
+		source:          source,
+		region:          region,
+		endpoint:        endpoint,
+		httpClient:      httpClient,
+		roleTrn:         config.RoleTrn.ValueString(),
+		sessionName:     config.SessionName.ValueString(),
+		durationSeconds: int(config.DurationSeconds.ValueInt64()),
+		policy:          config.Policy.ValueString(),
+	}
+
+	if provider.sessionName == "" {
+		provider.sessionName = defaultAssumeRoleSessionName
+	}
+	if provider.durationSeconds == 0 {
+		provider.durationSeconds = defaultAssumeRoleDuration
+	}
+
+	return credentials.NewExpireAbleCredentials(provider)
+}
+
+// Retrieve assumes the role with the current source credentials.
+func (p *assumeRoleProvider) Retrieve() (credentials.Value, error) {
+	sourceCredentials, err := p.source.GetBase(p.region, byteplusStsClient.ServiceName)
+	if err != nil {
+		return credentials.Value{ProviderName: assumeRoleProviderName}, err
+	}
+
+	stsClient := newStsClient(sourceCredentials, p.endpoint, p.httpClient)
+	roleCredentials, err := assumeRole(context.Background(), stsClient, &byteplusStsClient.AssumeRoleRequest{
+		DurationSeconds: p.durationSeconds,
+		Policy:          p.policy,
+		RoleTrn:         p.roleTrn,
+		RoleSessionName: p.sessionName,
+	})
+	if err != nil {
+		return credentials.Value{ProviderName: assumeRoleProviderName}, fmt.Errorf("failed to assume role %s: %w", p.roleTrn, err)
+	}
+
+	expiredTime, err := time.Parse(time.RFC3339, roleCredentials.ExpiredTime)
+	if err != nil {
+		// Fallback to the requested duration if the expired time is unknown.
+		expiredTime = time.Now().Add(time.Duration(p.durationSeconds) * time.Second)
+	}
+	p.SetExpiration(expiredTime, assumeRoleCredentialsExpiryWindow)
+
+	return credentials.Value{
+		AccessKeyID:     roleCredentials.AccessKeyId,
+		SecretAccessKey: roleCredentials.SecretAccessKey,
+		SessionToken:    roleCredentials.SessionToken,
+		ProviderName:    assumeRoleProviderName,
+	}, nil
+}
+
+// assumeRole calls STS AssumeRole once with the credentials of the STS
+// client, the session token of the credentials is signed into the request.
+//
+// Parameters:
+//   - ctx: Context.
+//   - stsClient: The STS client with the source credentials.
+//   - request: The role, session name, duration and the optional session policy.
+//
+// Returns:
+//   - roleCredentials: The temporary credentials of the assumed role.
+//   - err: The error returned from STS API or of parsing the response.
+func assumeRole(ctx context.Context, stsClient *byteplusBaseClient.Client, request *byteplusStsClient.AssumeRoleRequest) (roleCredentials *byteplusStsClient.Credentials, err error) {
+	query := url.Values{
+		"RoleTrn":         []string{request.RoleTrn},
+		"RoleSessionName": []string{request.RoleSessionName},
+		"DurationSeconds": []string{strconv.Itoa(request.DurationSeconds)},
+	}
+	if request.Policy != "" {
+		query.Set("Policy", request.Policy)
+	}
+
+	var response byteplusStsClient.AssumeRoleResp
+	err = logStsApiCall(ctx, stsClient, stsActionAssumeRole, 1, func() error {
+		body, _, err := stsClient.Query(stsActionAssumeRole, query)
+		// The body of the failed requests carries the error code.
+		if len(body) > 0 {
+			if jsonErr := json.Unmarshal(body, &response); jsonErr != nil && err == nil {
+				return jsonErr
+			}
+		}
+		if response.ResponseMetadata.Error != nil {
+			return bytepluserr.New(response.ResponseMetadata.Error.Code, response.ResponseMetadata.Error.Message, err)
+		}
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if response.Result == nil || response.Result.Credentials == nil {
+		return nil, fmt.Errorf("no credentials returned from %s", stsActionAssumeRole)
+	}
+
+	return response.Result.Credentials, nil
+}
+
+// validateAssumeRoleConfig validates the assume role block.
+//
+// Parameters:
+//   - config: The assume role configurations.
+//   - blockPath: The path of the assume role block.
+//
+// Returns:
+//   - diags: The validation errors.
+func validateAssumeRoleConfig(config *assumeRoleConfig, blockPath path.Path) (diags diag.Diagnostics) {
+	if config.RoleTrn.IsUnknown() || config.SessionName.IsUnknown() ||
+		config.DurationSeconds.IsUnknown() || config.Policy.IsUnknown() {
+		diags.AddAttributeError(
+			blockPath,
+			"Unknown Byteplus assume role configuration",
+			"The assume role configuration must be known before the Byteplus API client can be created.",
+		)
+		return
+	}
+
+	if config.RoleTrn.ValueString() == "" {
+		diags.AddAttributeError(
+			blockPath.AtName("role_trn"),
+			"Missing Byteplus assume role TRN",
+			"The TRN of the role to assume must not be empty, e.g. trn:iam::2100000000:role/terraform.",
+		)
+	} else if _, _, ok := parseRoleTrn(config.RoleTrn.ValueString()); !ok {
+		diags.AddAttributeError(
+			blockPath.AtName("role_trn"),
+			"Invalid Byteplus assume role TRN",
+			fmt.Sprintf("The TRN of the role to assume must be in the format of trn:iam::<account ID>:role/<role name>, got %q.",
+				config.RoleTrn.ValueString()),
+		)
+	}
+
+	if !config.DurationSeconds.IsNull() {
+		duration := config.DurationSeconds.ValueInt64()
+		if duration < minAssumeRoleDuration || duration > maxAssumeRoleDuration {
+			diags.AddAttributeError(
+				blockPath.AtName("duration_seconds"),
+				"Invalid Byteplus assume role duration",
+				fmt.Sprintf("The duration of the assumed role session must be between %d and %d seconds.",
+					minAssumeRoleDuration, maxAssumeRoleDuration),
+			)
+		}
+	}
+
+	return
+}
+
+// callerIdentity is the identity of the credentials returned from STS
+// GetCallerIdentity API.
+type callerIdentity struct {
+	AccountId    string
+	Trn          string
+	IdentityType string
+	IdentityId   string
+}
+
+type getCallerIdentityResponse struct {
+	ResponseMetadata byteplusBaseClient.ResponseMetadata
+	Result           *callerIdentity `json:",omitempty"`
+}
+
+// getCallerIdentity performs one cheap authenticated call to STS API to
+// resolve the identity of the credentials, it requires no permissions, so bad
+// or revoked keys fail once at configure time instead of failing every
+// resources.
+//
+// Parameters:
+//   - ctx: Context.
+//   - stsClient: The STS client with the credentials to validate.
+//   - roleTrn: The TRN of the assumed role, used to resolve the account ID if it is missing in the response.
+//
+// Returns:
+//   - identity: The identity of the credentials.
+//   - err: The error if the credentials are invalid or cannot be validated.
+func getCallerIdentity(ctx context.Context, stsClient *byteplusBaseClient.Client, roleTrn string) (identity *callerIdentity, err error) {
+	var response getCallerIdentityResponse
+
+	attempt := 0
+	getIdentity := func() error {
+		attempt++
+		err := logStsApiCall(ctx, stsClient, stsActionGetCallerIdentity, attempt, func() error {
+			body, _, err := stsClient.Query(stsActionGetCallerIdentity, url.Values{})
+			// The body of the failed requests carries the error code.
+			if len(body) > 0 {
+				if jsonErr := json.Unmarshal(body, &response); jsonErr != nil && err == nil {
+					return jsonErr
+				}
+			}
+			if response.ResponseMetadata.Error != nil {
+				return bytepluserr.New(response.ResponseMetadata.Error.Code, response.ResponseMetadata.Error.Message, err)
+			}
+			return err
+		})
+		if err != nil {
+			if isAbleToRetry(apiErrorCode(err)) {
+				return err
+			}
+			return backoff.Permanent(err)
+		}
+		return nil
+	}
+
+	reconnectBackoff := backoff.NewExponentialBackOff()
+	reconnectBackoff.MaxElapsedTime = 30 * time.Second
+	if err = backoff.Retry(getIdentity, reconnectBackoff); err != nil {
+		return nil, err
+	}
+
+	identity = &callerIdentity{}
+	if response.Result != nil {
+		identity = response.Result
+	}
+	if identity.AccountId == "" {
+		identity.AccountId = accountIdFromTrn(identity.Trn)
+	}
+	if identity.AccountId == "" {
+		identity.AccountId = accountIdFromTrn(roleTrn)
+	}
+	if identity.AccountId == "" {
+		return nil, fmt.Errorf("no account ID returned from %s", stsActionGetCallerIdentity)
+	}
+
+	return identity, nil
+}
+
+// accountIdFromTrn returns the account ID in the TRN, e.g. 2100000000 of
+// trn:iam::2100000000:role/terraform.
+func accountIdFromTrn(trn string) string {
+	parts := strings.Split(trn, ":")
+	if len(parts) < 5 || parts[0] != "trn" {
+		return ""
+	}
+
+	return parts[3]
+}
+
+// parseRoleTrn returns the account ID and the role name in the role TRN, e.g.
+// 2100000000 and terraform of trn:iam::2100000000:role/terraform.
+func parseRoleTrn(trn string) (accountId, roleName string, ok bool) {
+	accountId = accountIdFromTrn(trn)
+	parts := strings.SplitN(trn, ":", 5)
+	if accountId == "" || len(parts) < 5 || parts[1] != "iam" {
+		return "", "", false
+	}
+
+	roleName = strings.TrimPrefix(parts[4], "role/")
+	if roleName == parts[4] || roleName == "" {
+		return "", "", false
+	}
+
+	return accountId, roleName, true
+}
@@ -0,0 +1,474 @@
+package byteplus
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/byteplus-sdk/byteplus-go-sdk-v2/byteplus/credentials"
+	byteplusBaseClient "github.com/byteplus-sdk/byteplus-sdk-golang/base"
+	byteplusStsClient "github.com/byteplus-sdk/byteplus-sdk-golang/service/sts"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func writeSharedCredentialsFile(t *testing.T, content string) string {
+	t.Helper()
+
+	filename := filepath.Join(t.TempDir(), "credentials")
+	if err := os.WriteFile(filename, []byte(content), 0o600); err != nil {
+		t.Fatalf("failed to write shared credentials file: %v", err)
+	}
+
+	return filename
+}
+
+func TestReadSharedCredentialsFile(t *testing.T) {
+	content := `
+# comment at the top
+[default]
+byteplus_access_key_id = AKDEFAULT
+byteplus_secret_access_key = SKDEFAULT
+region = ap-singapore-1
+
+; comment with semicolon
+[profile dev]
+byteplus_access_key_id=AKDEV
+  byteplus_secret_access_key =  SKDEV
+byteplus_session_token = TOKEN=WITH=EQUALS
+# byteplus_access_key_id = AKCOMMENTED
+not a key value line
+
+[process]
+credential_process = /usr/local/bin/vault-helper --role terraform
+
+[empty]
+`
+	filename := writeSharedCredentialsFile(t, content)
+
+	tests := []struct {
+		name       string
+		profile    string
+		wantValues map[string]string
+		wantFound  bool
+	}{
+		{
+			name:    "default profile",
+			profile: "default",
+			wantValues: map[string]string{
+				sharedCredentialsAccessKey: "AKDEFAULT",
+				sharedCredentialsSecretKey: "SKDEFAULT",
+				sharedCredentialsRegion:    "ap-singapore-1",
+			},
+			wantFound: true,
+		},
+		{
+			name:    "profile prefix with comments and spaces",
+			profile: "dev",
+			wantValues: map[string]string{
+				sharedCredentialsAccessKey:    "AKDEV",
+				sharedCredentialsSecretKey:    "SKDEV",
+				sharedCredentialsSessionToken: "TOKEN=WITH=EQUALS",
+			},
+			wantFound: true,
+		},
+		{
+			name:    "credential process",
+			profile: "process",
+			wantValues: map[string]string{
+				sharedCredentialsProcess: "/usr/local/bin/vault-helper --role terraform",
+			},
+			wantFound: true,
+		},
+		{
+			name:       "profile without keys",
+			profile:    "empty",
+			wantValues: map[string]string{},
+			wantFound:  true,
+		},
+		{
+			name:       "missing profile",
+			profile:    "missing",
+			wantValues: map[string]string{},
+			wantFound:  false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			values, found, err := readSharedCredentialsFile(filename, tt.profile)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if found != tt.wantFound {
+				t.Errorf("found = %v, want %v", found, tt.wantFound)
+			}
+			if !reflect.DeepEqual(values, tt.wantValues) {
+				t.Errorf("values = %v, want %v", values, tt.wantValues)
+			}
+		})
+	}
+}
+
+func TestReadSharedCredentialsFileNotExist(t *testing.T) {
+	values, found, err := readSharedCredentialsFile(filepath.Join(t.TempDir(), "missing"), "default")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if found || values != nil {
+		t.Errorf("got values %v and found %v, want no profile", values, found)
+	}
+}
+
+func TestLoadSharedCredentialsProfile(t *testing.T) {
+	first := writeSharedCredentialsFile(t, `
+[default]
+byteplus_access_key_id = AKFIRST
+byteplus_secret_access_key = SKFIRST
+`)
+	second := writeSharedCredentialsFile(t, `
+[default]
+byteplus_access_key_id = AKSECOND
+byteplus_secret_access_key = SKSECOND
+
+[dev]
+byteplus_access_key_id = AKDEV
+byteplus_secret_access_key = SKDEV
+region = ap-southeast-1
+`)
+	missing := filepath.Join(t.TempDir(), "missing")
+
+	tests := []struct {
+		name      string
+		filenames []string
+		profile   string
+		want      *sharedCredentialsProfile
+	}{
+		{
+			name:      "first file wins",
+			filenames: []string{first, second},
+			profile:   "default",
+			want: &sharedCredentialsProfile{
+				Name:      "default",
+				Filename:  first,
+				AccessKey: "AKFIRST",
+				SecretKey: "SKFIRST",
+			},
+		},
+		{
+			name:      "falls through to the file with the profile",
+			filenames: []string{missing, first, second},
+			profile:   "dev",
+			want: &sharedCredentialsProfile{
+				Name:      "dev",
+				Filename:  second,
+				AccessKey: "AKDEV",
+				SecretKey: "SKDEV",
+				Region:    "ap-southeast-1",
+			},
+		},
+		{
+			name:      "profile not found",
+			filenames: []string{missing, first, second},
+			profile:   "prod",
+			want:      nil,
+		},
+		{
+			name:      "no files",
+			filenames: nil,
+			profile:   "default",
+			want:      nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := loadSharedCredentialsProfile(tt.filenames, tt.profile)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("profile = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExpandHomeDir(t *testing.T) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		t.Skipf("no home directory: %v", err)
+	}
+
+	tests := []struct {
+		path string
+		want string
+	}{
+		{path: "~", want: homeDir},
+		{path: "~/.byteplus/credentials", want: filepath.Join(homeDir, ".byteplus", "credentials")},
+		{path: "/etc/byteplus/credentials", want: "/etc/byteplus/credentials"},
+		{path: "~other/credentials", want: "~other/credentials"},
+		{path: "", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			got, err := expandHomeDir(tt.path)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("expandHomeDir(%q) = %q, want %q", tt.path, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseRoleTrn(t *testing.T) {
+	tests := []struct {
+		trn           string
+		wantAccountId string
+		wantRoleName  string
+		wantOk        bool
+	}{
+		{trn: "trn:iam::2100000000:role/terraform", wantAccountId: "2100000000", wantRoleName: "terraform", wantOk: true},
+		{trn: "trn:iam::2100000000:role/", wantOk: false},
+		{trn: "trn:iam::2100000000:user/terraform", wantOk: false},
+		{trn: "trn:cdn::2100000000:role/terraform", wantOk: false},
+		{trn: "trn:iam::2100000000", wantOk: false},
+		{trn: "arn:aws:iam::2100000000:role/terraform", wantOk: false},
+		{trn: "", wantOk: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.trn, func(t *testing.T) {
+			accountId, roleName, ok := parseRoleTrn(tt.trn)
+			if accountId != tt.wantAccountId || roleName != tt.wantRoleName || ok != tt.wantOk {
+				t.Errorf("parseRoleTrn(%q) = (%q, %q, %v), want (%q, %q, %v)",
+					tt.trn, accountId, roleName, ok, tt.wantAccountId, tt.wantRoleName, tt.wantOk)
+			}
+		})
+	}
+}
+
+func TestAccountIdFromTrn(t *testing.T) {
+	tests := []struct {
+		trn  string
+		want string
+	}{
+		{trn: "trn:iam::2100000000:role/terraform", want: "2100000000"},
+		{trn: "trn:sts::2100000000:assumed-role/terraform/session", want: "2100000000"},
+		{trn: "trn:iam::2100000000:user/terraform", want: "2100000000"},
+		{trn: "trn:iam::2100000000", want: ""},
+		{trn: "arn:aws:iam::2100000000:role/terraform", want: ""},
+		{trn: "", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.trn, func(t *testing.T) {
+			if got := accountIdFromTrn(tt.trn); got != tt.want {
+				t.Errorf("accountIdFromTrn(%q) = %q, want %q", tt.trn, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetCallerIdentity(t *testing.T) {
+	tests := []struct {
+		name          string
+		statusCode    int
+		body          string
+		roleTrn       string
+		wantAccountId string
+		wantErrorCode string
+	}{
+		{
+			name:          "account ID in response",
+			statusCode:    http.StatusOK,
+			body:          `{"ResponseMetadata":{"RequestId":"1"},"Result":{"AccountId":"2100000000","Trn":"trn:iam::2100000000:user/terraform","IdentityType":"User"}}`,
+			wantAccountId: "2100000000",
+		},
+		{
+			name:          "account ID from identity TRN",
+			statusCode:    http.StatusOK,
+			body:          `{"ResponseMetadata":{"RequestId":"1"},"Result":{"Trn":"trn:sts::2100000001:assumed-role/terraform/session"}}`,
+			wantAccountId: "2100000001",
+		},
+		{
+			name:          "account ID from assumed role TRN",
+			statusCode:    http.StatusOK,
+			body:          `{"ResponseMetadata":{"RequestId":"1"},"Result":{}}`,
+			roleTrn:       "trn:iam::2100000002:role/terraform",
+			wantAccountId: "2100000002",
+		},
+		{
+			name:          "no account ID",
+			statusCode:    http.StatusOK,
+			body:          `{"ResponseMetadata":{"RequestId":"1"}}`,
+			wantErrorCode: "",
+		},
+		{
+			name:          "invalid access key",
+			statusCode:    http.StatusUnauthorized,
+			body:          `{"ResponseMetadata":{"RequestId":"1","Error":{"Code":"InvalidAccessKey","Message":"invalid"}}}`,
+			wantErrorCode: ERR_CODE_INVALID_ACCESS_KEY,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if action := r.URL.Query().Get("Action"); action != stsActionGetCallerIdentity {
+					t.Errorf("Action = %q, want %q", action, stsActionGetCallerIdentity)
+				}
+				w.WriteHeader(tt.statusCode)
+				_, _ = w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			stsClient := newStsClient(byteplusBaseClient.Credentials{
+				AccessKeyID:     "AKTEST",
+				SecretAccessKey: "SKTEST",
+				Region:          "ap-singapore-1",
+			}, server.URL, server.Client())
+
+			identity, err := getCallerIdentity(context.Background(), stsClient, tt.roleTrn)
+			if tt.wantAccountId == "" {
+				if err == nil {
+					t.Fatalf("got identity %+v, want error", identity)
+				}
+				if code := apiErrorCode(err); code != tt.wantErrorCode {
+					t.Errorf("error code = %q, want %q", code, tt.wantErrorCode)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if identity.AccountId != tt.wantAccountId {
+				t.Errorf("account ID = %q, want %q", identity.AccountId, tt.wantAccountId)
+			}
+		})
+	}
+}
+
+func TestAssumeRoleCredentials(t *testing.T) {
+	var gotQuery url.Values
+	var gotSecurityToken string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotQuery = r.URL.Query()
+		gotSecurityToken = r.Header.Get("X-Security-Token")
+		if gotSecurityToken == "" {
+			gotSecurityToken = gotQuery.Get("X-Security-Token")
+		}
+		_, _ = w.Write([]byte(`{"ResponseMetadata":{"RequestId":"1"},"Result":{"Credentials":{` +
+			`"AccessKeyId":"AKROLE","SecretAccessKey":"SKROLE","SessionToken":"TOKENROLE",` +
+			`"ExpiredTime":"` + time.Now().Add(time.Hour).UTC().Format(time.RFC3339) + `"}}}`))
+	}))
+	defer server.Close()
+
+	source := credentials.NewStaticCredentials("AKSOURCE", "SKSOURCE", "TOKENSOURCE")
+	roleCredentials := newAssumeRoleCredentials(source, "ap-singapore-1", server.URL, server.Client(), &assumeRoleConfig{
+		RoleTrn:         types.StringValue("trn:iam::2100000000:role/terraform"),
+		SessionName:     types.StringNull(),
+		DurationSeconds: types.Int64Value(900),
+		Policy:          types.StringValue(`{"Statement":[]}`),
+	})
+
+	value, err := roleCredentials.Get()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if value.AccessKeyID != "AKROLE" || value.SecretAccessKey != "SKROLE" || value.SessionToken != "TOKENROLE" {
+		t.Errorf("credentials = %+v, want the credentials of the role", value)
+	}
+
+	wantQuery := map[string]string{
+		"Action":          stsActionAssumeRole,
+		"RoleTrn":         "trn:iam::2100000000:role/terraform",
+		"RoleSessionName": defaultAssumeRoleSessionName,
+		"DurationSeconds": "900",
+		"Policy":          `{"Statement":[]}`,
+	}
+	for key, want := range wantQuery {
+		if got := gotQuery.Get(key); got != want {
+			t.Errorf("query %s = %q, want %q", key, got, want)
+		}
+	}
+	if gotSecurityToken != "TOKENSOURCE" {
+		t.Errorf("security token = %q, want the session token of the source credentials", gotSecurityToken)
+	}
+}
+
+func TestAssumeRoleError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		_, _ = w.Write([]byte(`{"ResponseMetadata":{"RequestId":"1","Error":{"Code":"AccessDenied","Message":"denied"}}}`))
+	}))
+	defer server.Close()
+
+	stsClient := newStsClient(byteplusBaseClient.Credentials{
+		AccessKeyID:     "AKTEST",
+		SecretAccessKey: "SKTEST",
+		Region:          "ap-singapore-1",
+	}, server.URL, server.Client())
+
+	_, err := assumeRole(context.Background(), stsClient, &byteplusStsClient.AssumeRoleRequest{
+		RoleTrn:         "trn:iam::2100000000:role/terraform",
+		RoleSessionName: defaultAssumeRoleSessionName,
+		DurationSeconds: defaultAssumeRoleDuration,
+	})
+	if code := apiErrorCode(err); code != "AccessDenied" {
+		t.Errorf("error = %v, want code AccessDenied", err)
+	}
+}
+
+// recordingTransport records the URLs of the requests sent through it, like
+// the transport of a proxy.
+type recordingTransport struct {
+	urls []string
+}
+
+func (t *recordingTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	t.urls = append(t.urls, r.URL.String())
+	return http.DefaultTransport.RoundTrip(r)
+}
+
+func TestAssumeRoleTransport(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"ResponseMetadata":{"RequestId":"1"},"Result":{"Credentials":{` +
+			`"AccessKeyId":"AKROLE","SecretAccessKey":"SKROLE","SessionToken":"TOKENROLE"}}}`))
+	}))
+	defer server.Close()
+
+	transport := &recordingTransport{}
+	roleCredentials := newAssumeRoleCredentials(
+		credentials.NewStaticCredentials("AKSOURCE", "SKSOURCE", ""),
+		"ap-singapore-1",
+		server.URL,
+		&http.Client{Transport: transport, Timeout: time.Minute},
+		&assumeRoleConfig{
+			RoleTrn:         types.StringValue("trn:iam::2100000000:role/terraform"),
+			SessionName:     types.StringValue("terraform"),
+			DurationSeconds: types.Int64Null(),
+			Policy:          types.StringNull(),
+		},
+	)
+
+	if _, err := roleCredentials.Get(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(transport.urls) != 1 {
+		t.Fatalf("requests through the transport = %d, want 1", len(transport.urls))
+	}
+	if !strings.HasPrefix(transport.urls[0], server.URL+"/") {
+		t.Errorf("request URL = %q, want the scheme and host of %q", transport.urls[0], server.URL)
+	}
+}
@@ -0,0 +1,98 @@
+package byteplus
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &callerIdentityDataSource{}
+	_ datasource.DataSourceWithConfigure = &callerIdentityDataSource{}
+)
+
+func NewCallerIdentityDataSource() datasource.DataSource {
+	return &callerIdentityDataSource{}
+}
+
+type callerIdentityDataSource struct {
+	clients *clientFactory
+
+	// The identity resolved when the provider validated the credentials, nil
+	// if the validation is skipped.
+	identity *callerIdentity
+}
+
+type callerIdentityDataSourceModel struct {
+	AccountId    types.String `tfsdk:"account_id"`
+	Trn          types.String `tfsdk:"trn"`
+	IdentityType types.String `tfsdk:"identity_type"`
+	IdentityId   types.String `tfsdk:"identity_id"`
+}
+
+// Metadata returns the data source type name.
+func (d *callerIdentityDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_caller_identity"
+}
+
+func (d *callerIdentityDataSource) Schema(_ context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "This data source provides the identity of the credentials of the provider, resolved " +
+			"with STS GetCallerIdentity API.",
+		Attributes: map[string]schema.Attribute{
+			"account_id": schema.StringAttribute{
+				Description: "The ID of the account that owns the credentials.",
+				Computed:    true,
+			},
+			"trn": schema.StringAttribute{
+				Description: "The TRN of the user or the assumed role of the credentials.",
+				Computed:    true,
+			},
+			"identity_type": schema.StringAttribute{
+				Description: "The type of the identity, e.g. `Account`, `User` or `AssumedRole`.",
+				Computed:    true,
+			},
+			"identity_id": schema.StringAttribute{
+				Description: "The ID of the user or the assumed role session of the credentials.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func (d *callerIdentityDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	d.clients = req.ProviderData.(byteplusClients).clients
+	d.identity = req.ProviderData.(byteplusClients).identity
+}
+
+func (d *callerIdentityDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	identity := d.identity
+	if identity == nil {
+		var err error
+		identity, err = d.clients.callerIdentity(ctx)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"[API ERROR] Failed to Get Caller Identity",
+				err.Error(),
+			)
+			return
+		}
+	}
+
+	state := &callerIdentityDataSourceModel{
+		AccountId:    types.StringValue(identity.AccountId),
+		Trn:          types.StringValue(identity.Trn),
+		IdentityType: types.StringValue(identity.IdentityType),
+		IdentityId:   types.StringValue(identity.IdentityId),
+	}
+
+	diags := resp.State.Set(ctx, state)
+	resp.Diagnostics.Append(diags...)
+}
@@ -0,0 +1,172 @@
+package byteplus
+
+import (
+	"context"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource = &cdnCertificateInfoDataSource{}
+)
+
+func NewCdnCertificateInfoDataSource() datasource.DataSource {
+	return &cdnCertificateInfoDataSource{}
+}
+
+// cdnCertificateInfoDataSource parses the certificates locally, so it needs
+// no client.
+type cdnCertificateInfoDataSource struct{}
+
+type cdnCertificateInfoDataSourceModel struct {
+	Certificate             types.String `tfsdk:"certificate"`
+	Domain                  types.String `tfsdk:"domain_name"`
+	Subject                 types.String `tfsdk:"subject"`
+	CommonName              types.String `tfsdk:"common_name"`
+	Issuer                  types.String `tfsdk:"issuer"`
+	SubjectAlternativeNames []string     `tfsdk:"subject_alternative_names"`
+	NotBefore               types.String `tfsdk:"not_before"`
+	NotAfter                types.String `tfsdk:"not_after"`
+	Expired                 types.Bool   `tfsdk:"expired"`
+	SerialNumber            types.String `tfsdk:"serial_number"`
+	Fingerprint             types.String `tfsdk:"fingerprint"`
+	Sha1Fingerprint         types.String `tfsdk:"sha1_fingerprint"`
+	ChainLength             types.Int64  `tfsdk:"chain_length"`
+	ChainError              types.String `tfsdk:"chain_error"`
+	CoversDomain            types.Bool   `tfsdk:"covers_domain"`
+}
+
+// Metadata returns the data source type name.
+func (d *cdnCertificateInfoDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_cdn_certificate_info"
+}
+
+func (d *cdnCertificateInfoDataSource) Schema(_ context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "This data source parses a PEM certificate chain locally without calling any API, " +
+			"to inspect the certificate before it is uploaded or bound to CDN domains.",
+		Attributes: map[string]schema.Attribute{
+			"certificate": schema.StringAttribute{
+				Description: "The certificate in PEM format, the leaf certificate first followed by the " +
+					"intermediate certificates.",
+				Required: true,
+			},
+			"domain_name": schema.StringAttribute{
+				Description: "The CDN domain name to check whether it is covered by the certificate.",
+				Optional:    true,
+			},
+			"subject": schema.StringAttribute{
+				Description: "The subject of the leaf certificate.",
+				Computed:    true,
+			},
+			"common_name": schema.StringAttribute{
+				Description: "The common name of the subject of the leaf certificate.",
+				Computed:    true,
+			},
+			"issuer": schema.StringAttribute{
+				Description: "The issuer of the leaf certificate.",
+				Computed:    true,
+			},
+			"subject_alternative_names": schema.ListAttribute{
+				Description: "The DNS names and IP addresses of the leaf certificate.",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+			"not_before": schema.StringAttribute{
+				Description: "The time the leaf certificate becomes valid in RFC3339 format.",
+				Computed:    true,
+			},
+			"not_after": schema.StringAttribute{
+				Description: "The expiry time of the leaf certificate in RFC3339 format.",
+				Computed:    true,
+			},
+			"expired": schema.BoolAttribute{
+				Description: "Whether the leaf certificate has expired when the data source is read.",
+				Computed:    true,
+			},
+			"serial_number": schema.StringAttribute{
+				Description: "The serial number of the leaf certificate in hex.",
+				Computed:    true,
+			},
+			"fingerprint": schema.StringAttribute{
+				Description: "The SHA-256 fingerprint of the leaf certificate in hex.",
+				Computed:    true,
+			},
+			"sha1_fingerprint": schema.StringAttribute{
+				Description: "The SHA-1 fingerprint of the leaf certificate in hex.",
+				Computed:    true,
+			},
+			"chain_length": schema.Int64Attribute{
+				Description: "The number of certificates in the chain.",
+				Computed:    true,
+			},
+			"chain_error": schema.StringAttribute{
+				Description: "Why the chain would be rejected, e.g. an intermediate certificate is " +
+					"missing (InvalidParameter.Https.CertInfo.ChainMissing) or the certificate has " +
+					"expired. Empty if the chain is complete.",
+				Computed: true,
+			},
+			"covers_domain": schema.BoolAttribute{
+				Description: "Whether the leaf certificate covers `domain_name`, a wildcard name covers " +
+					"a single level of subdomains only. Null if `domain_name` is not set.",
+				Computed: true,
+			},
+		},
+	}
+}
+
+func (d *cdnCertificateInfoDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var plan cdnCertificateInfoDataSourceModel
+	diags := req.Config.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	certs, err := parseCertificateChain(plan.Certificate.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("certificate"),
+			"Invalid Certificate",
+			err.Error(),
+		)
+		return
+	}
+
+	now := time.Now()
+	leaf := certs[0]
+	state := plan
+	state.Subject = types.StringValue(leaf.Subject.String())
+	state.CommonName = types.StringValue(leaf.Subject.CommonName)
+	state.Issuer = types.StringValue(leaf.Issuer.String())
+	state.SubjectAlternativeNames = certificateSubjectAlternativeNames(leaf)
+	state.NotBefore = types.StringValue(leaf.NotBefore.UTC().Format(time.RFC3339))
+	state.NotAfter = types.StringValue(leaf.NotAfter.UTC().Format(time.RFC3339))
+	state.Expired = types.BoolValue(now.After(leaf.NotAfter))
+	state.SerialNumber = types.StringValue(leaf.SerialNumber.Text(16))
+	state.Fingerprint = types.StringValue(certificateFingerprint(leaf))
+	state.Sha1Fingerprint = types.StringValue(certificateSha1Fingerprint(leaf))
+	state.ChainLength = types.Int64Value(int64(len(certs)))
+
+	state.ChainError = types.StringValue("")
+	if err := validateCertificateChain(certs, now); err != nil {
+		state.ChainError = types.StringValue(err.Error())
+	}
+
+	state.CoversDomain = types.BoolNull()
+	if domainName := plan.Domain.ValueString(); domainName != "" {
+		state.CoversDomain = types.BoolValue(certificateCoversDomain(leaf, domainName))
+	}
+
+	// Set state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
@@ -3,13 +3,13 @@ package byteplus
 import (
 	"context"
 	"fmt"
-	"time"
 
+	"github.com/byteplus-sdk/byteplus-go-sdk-v2/byteplus"
 	byteplusCdnClient "github.com/byteplus-sdk/byteplus-sdk-golang/service/cdn"
-	"github.com/cenkalti/backoff/v4"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
@@ -25,14 +25,41 @@ func NewCdnDomainDataSource() datasource.DataSource {
 }
 
 type cdnDomainDataSource struct {
-	client *byteplusCdnClient.CDN
+	clients *clientFactory
 }
 
 type cdnDomainDataSourceModel struct {
-	ClientConfig *clientConfig `tfsdk:"client_config"`
-	Domain       types.String  `tfsdk:"domain_name"`
-	Cname        types.String  `tfsdk:"cname"`
-	Status       types.String  `tfsdk:"status"`
+	ClientConfig   *clientConfig `tfsdk:"client_config"`
+	Domain         types.String  `tfsdk:"domain_name"`
+	Cname          types.String  `tfsdk:"cname"`
+	Status         types.String  `tfsdk:"status"`
+	WaitForStatus  types.String  `tfsdk:"wait_for_status"`
+	WaitTimeout    types.String  `tfsdk:"wait_timeout"`
+	FailIfNotFound types.Bool    `tfsdk:"fail_if_not_found"`
+	Exists         types.Bool    `tfsdk:"exists"`
+
+	ServiceType    types.String            `tfsdk:"service_type"`
+	ServiceRegion  types.String            `tfsdk:"service_region"`
+	ProjectName    types.String            `tfsdk:"project_name"`
+	OriginProtocol types.String            `tfsdk:"origin_protocol"`
+	OriginHost     types.String            `tfsdk:"origin_host"`
+	Origins        []*cdnDomainOriginModel `tfsdk:"origins"`
+	Https          *cdnDomainHttpsModel    `tfsdk:"https"`
+	IPv6           types.Bool              `tfsdk:"ipv6"`
+	Tags           types.Map               `tfsdk:"tags"`
+	LockStatus     types.String            `tfsdk:"lock_status"`
+	CreateTime     types.String            `tfsdk:"create_time"`
+	UpdateTime     types.String            `tfsdk:"update_time"`
+}
+
+type cdnDomainHttpsModel struct {
+	Enabled        types.Bool   `tfsdk:"enabled"`
+	CertId         types.String `tfsdk:"cert_id"`
+	CertName       types.String `tfsdk:"cert_name"`
+	CertExpireTime types.String `tfsdk:"cert_expire_time"`
+	Http2          types.Bool   `tfsdk:"http2"`
+	ForcedRedirect types.Bool   `tfsdk:"forced_redirect"`
+	TlsVersions    types.List   `tfsdk:"tls_versions"`
 }
 
 // Metadata returns the data source type name.
@@ -56,32 +83,141 @@ func (d *cdnDomainDataSource) Schema(_ context.Context, req datasource.SchemaReq
 				Description: "Status of CDN domain.",
 				Computed:    true,
 			},
-		},
-
-		Blocks: map[string]schema.Block{
-			"client_config": schema.SingleNestedBlock{
-				Description: "Config to override default client created in Provider. " +
-					"This block will not be recorded in state file.",
+			"wait_for_status": schema.StringAttribute{
+				Description: "Wait until the CDN domain reaches the status, valid values are `online` " +
+					"and `offline`. The domain is polled with backoff, including while it is not " +
+					"added yet or still configuring. Default to not wait.",
+				Optional: true,
+			},
+			"wait_timeout": schema.StringAttribute{
+				Description: "The maximum time to wait for `wait_for_status`, e.g. `10m`. Default to `20m`.",
+				Optional:    true,
+			},
+			"fail_if_not_found": schema.BoolAttribute{
+				Description: "Whether to fail with an error if the CDN domain is not found. When " +
+					"`false`, the attributes of the domain are null if it is not found. Default to `false`.",
+				Optional: true,
+			},
+			"exists": schema.BoolAttribute{
+				Description: "Whether the CDN domain is found.",
+				Computed:    true,
+			},
+			"service_type": schema.StringAttribute{
+				Description: "The service type of CDN domain.",
+				Computed:    true,
+			},
+			"service_region": schema.StringAttribute{
+				Description: "The service region of CDN domain.",
+				Computed:    true,
+			},
+			"project_name": schema.StringAttribute{
+				Description: "The project of CDN domain.",
+				Computed:    true,
+			},
+			"origin_protocol": schema.StringAttribute{
+				Description: "The protocol to fetch from the origins.",
+				Computed:    true,
+			},
+			"origin_host": schema.StringAttribute{
+				Description: "The Host header to fetch from the origins.",
+				Computed:    true,
+			},
+			"origins": schema.ListNestedAttribute{
+				Description: "The origins of CDN domain.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"address": schema.StringAttribute{
+							Description: "The IP address or domain name of the origin.",
+							Computed:    true,
+						},
+						"instance_type": schema.StringAttribute{
+							Description: "The type of the origin address, `ip`, `domain` or `tos`.",
+							Computed:    true,
+						},
+						"origin_type": schema.StringAttribute{
+							Description: "Whether the origin is a `primary` or `backup` origin.",
+							Computed:    true,
+						},
+						"http_port": schema.Int64Attribute{
+							Description: "The port to fetch from the origin in HTTP.",
+							Computed:    true,
+						},
+						"https_port": schema.Int64Attribute{
+							Description: "The port to fetch from the origin in HTTPS.",
+							Computed:    true,
+						},
+						"weight": schema.Int64Attribute{
+							Description: "The weight of the origin among the origins of the same type.",
+							Computed:    true,
+						},
+						"origin_host": schema.StringAttribute{
+							Description: "The Host header to fetch from the origin.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+			"https": schema.SingleNestedAttribute{
+				Description: "The HTTPS configurations and the certificate bound to CDN domain.",
+				Computed:    true,
 				Attributes: map[string]schema.Attribute{
-					"region": schema.StringAttribute{
-						Description: "The region of the CDN domains. Default to " +
-							"use region configured in the provider.",
-						Optional: true,
+					"enabled": schema.BoolAttribute{
+						Description: "Whether HTTPS is enabled.",
+						Computed:    true,
+					},
+					"cert_id": schema.StringAttribute{
+						Description: "The ID of the certificate bound to CDN domain.",
+						Computed:    true,
+					},
+					"cert_name": schema.StringAttribute{
+						Description: "The name of the certificate bound to CDN domain.",
+						Computed:    true,
+					},
+					"cert_expire_time": schema.StringAttribute{
+						Description: "The expiry time of the certificate in RFC3339 format.",
+						Computed:    true,
 					},
-					"access_key": schema.StringAttribute{
-						Description: "The access key that have permissions to list " +
-							"CDN domains. Default to use access key configured in " +
-							"the provider.",
-						Optional: true,
+					"http2": schema.BoolAttribute{
+						Description: "Whether HTTP/2 is enabled.",
+						Computed:    true,
 					},
-					"secret_key": schema.StringAttribute{
-						Description: "The secret key that have permissions to list " +
-							"CDN domains. Default to use secret key configured in " +
-							"the provider.",
-						Optional: true,
+					"forced_redirect": schema.BoolAttribute{
+						Description: "Whether HTTP requests are redirected to HTTPS.",
+						Computed:    true,
+					},
+					"tls_versions": schema.ListAttribute{
+						Description: "The TLS versions enabled.",
+						Computed:    true,
+						ElementType: types.StringType,
 					},
 				},
 			},
+			"ipv6": schema.BoolAttribute{
+				Description: "Whether IPv6 is enabled.",
+				Computed:    true,
+			},
+			"tags": schema.MapAttribute{
+				Description: "The tags of CDN domain.",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+			"lock_status": schema.StringAttribute{
+				Description: "The lock status of CDN domain, a locked domain cannot be updated.",
+				Computed:    true,
+			},
+			"create_time": schema.StringAttribute{
+				Description: "The creation time of CDN domain in RFC3339 format.",
+				Computed:    true,
+			},
+			"update_time": schema.StringAttribute{
+				Description: "The last update time of CDN domain in RFC3339 format.",
+				Computed:    true,
+			},
+		},
+
+		Blocks: map[string]schema.Block{
+			"client_config": dataSourceClientConfigBlock("CDN domains", "list CDN domains"),
 		},
 	}
 }
@@ -92,7 +228,7 @@ func (d *cdnDomainDataSource) Configure(_ context.Context, req datasource.Config
 		return
 	}
 
-	d.client = req.ProviderData.(byteplusClients).cdnClient
+	d.clients = req.ProviderData.(byteplusClients).clients
 }
 
 func (d *cdnDomainDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
@@ -103,21 +239,14 @@ func (d *cdnDomainDataSource) Read(ctx context.Context, req datasource.ReadReque
 		return
 	}
 
-	if plan.ClientConfig == nil {
-		plan.ClientConfig = &clientConfig{}
-	}
-
-	initClient, clientCredentialsConfig, initClientDiags := initNewClient(d.client.Client, plan.ClientConfig)
-	if initClientDiags.HasError() {
-		resp.Diagnostics.Append(initClientDiags...)
+	// The client is local to this read, so the client_config of one instance
+	// never affects the other instances that are read in parallel.
+	client, clientDiags := d.clients.cdnClient(plan.ClientConfig)
+	resp.Diagnostics.Append(clientDiags...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	if initClient {
-		d.client = byteplusCdnClient.NewInstance()
-		d.client.Client.SetCredential(*clientCredentialsConfig)
-	}
-
 	domainName := plan.Domain.ValueString()
 
 	if domainName == "" {
@@ -129,64 +258,75 @@ func (d *cdnDomainDataSource) Read(ctx context.Context, req datasource.ReadReque
 		return
 	}
 
-	pageNum := int64(1)
-	pageSize := int64(100)
+	validateStringInSlice(&resp.Diagnostics, path.Root("wait_for_status"), plan.WaitForStatus,
+		[]string{cdnDomainStatusOnline, cdnDomainStatusOffline})
+	if resp.Diagnostics.HasError() {
+		return
+	}
 
-	// Create the request
-	ListCdnDomainsRequest := &byteplusCdnClient.ListCdnDomainsRequest{
-		Domain:   byteplusCdnClient.GetStrPtr(fmt.Sprintf("^%s$", domainName)),
-		PageNum:  &pageNum,
-		PageSize: &pageSize,
+	waitTimeout := parseWaitTimeout(&resp.Diagnostics, path.Root("wait_timeout"), plan.WaitTimeout, cdnDomainStatusTimeout)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
-	var response *byteplusCdnClient.ListCdnDomainsResponse
+	var cdnDomain *byteplusCdnClient.DomainSummary
 	var err error
-
-	describeCdnDomain := func() (err error) {
-		// Call the API
-		// Paging handling not needed, because it will always only output 1 CDN domain.
-		response, err = d.client.ListCdnDomains(ListCdnDomainsRequest)
+	if waitForStatus := plan.WaitForStatus.ValueString(); waitForStatus != "" {
+		cdnDomain, err = waitForCdnDomainStatus(ctx, client, domainName, waitForStatus, waitTimeout)
 		if err != nil {
-			if byteErr, ok := err.(byteplusCdnClient.CDNError); ok {
-				errCode := byteErr.Code
-				if isPermanentCommonError(errCode) || isPermanentCdnError(errCode) {
-					return backoff.Permanent(fmt.Errorf("err:\n%s", byteErr))
-				}
-
-				return fmt.Errorf("err:\n%s", errCode)
-			}
+			resp.Diagnostics.AddError(
+				"[API ERROR] Failed to Wait for CDN Domain Status.",
+				fmt.Sprintf("CDN domain %s failed to reach status %s with timeout %s.\n\n%s",
+					domainName, waitForStatus, waitTimeout, err.Error()),
+			)
+			return
+		}
+	} else {
+		cdnDomain, err = describeCdnDomain(ctx, client, domainName)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"[API ERROR] Failed to Describe CDN Domain.",
+				err.Error(),
+			)
+			return
 		}
-
-		return
 	}
 
-	// Retry with backoff
-	reconnectBackoff := backoff.NewExponentialBackOff()
-	reconnectBackoff.MaxElapsedTime = 30 * time.Second
-	err = backoff.Retry(describeCdnDomain, reconnectBackoff)
-	if err != nil {
-		resp.Diagnostics.AddError(
-			"[API ERROR] Failed to Describe CDN Domain.",
-			err.Error(),
+	if cdnDomain == nil && plan.FailIfNotFound.ValueBool() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("domain_name"),
+			"CDN Domain Not Found",
+			fmt.Sprintf("CDN domain %s is not found. Set fail_if_not_found to false to "+
+				"return null attributes instead.", domainName),
 		)
 		return
 	}
 
-	switch {
-	case len(response.Result.Data) == 1:
-		cdnDomain := response.Result.Data[0]
+	state.WaitForStatus = plan.WaitForStatus
+	state.WaitTimeout = plan.WaitTimeout
+	state.FailIfNotFound = plan.FailIfNotFound
+	state.Exists = types.BoolValue(cdnDomain != nil)
+	state.Tags = types.MapNull(types.StringType)
+	if cdnDomain != nil {
 		state.Domain = types.StringValue(cdnDomain.Domain)
 		state.Cname = types.StringValue(cdnDomain.Cname)
 		state.Status = types.StringValue(cdnDomain.Status)
-	case len(response.Result.Data) > 1:
-		// We will only expect 1 result as there will be no repeating CDN domain
-		// listed with 'domain name' filter.
-		resp.Diagnostics.AddError(
-			"[API ERROR] Multiple CDN Domain Found.",
-			"Multiple CDN domain found with domain name: "+domainName+".",
-		)
-	default:
-		// If not found, return null to avoid error in data source.
+
+		domainConfig, err := describeCdnDomainConfig(ctx, client, domainName)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"[API ERROR] Failed to Describe CDN Domain Config.",
+				err.Error(),
+			)
+			return
+		}
+
+		resp.Diagnostics.Append(state.setDomainConfig(ctx, cdnDomain, domainConfig)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	} else {
+		// If not found, return null unless fail_if_not_found is set.
 		state.Domain = types.StringNull()
 		state.Cname = types.StringNull()
 		state.Status = types.StringNull()
@@ -199,3 +339,49 @@ func (d *cdnDomainDataSource) Read(ctx context.Context, req datasource.ReadReque
 		return
 	}
 }
+
+// setDomainConfig sets the configurations of CDN domain from ListCdnDomains
+// and DescribeCdnConfig API.
+//
+// Parameters:
+//   - ctx: Context.
+//   - cdnDomain: The summary of CDN domain.
+//   - domainConfig: The configurations of CDN domain.
+//
+// Returns:
+//   - diags: Diagnostics of converting the tags and TLS versions.
+func (m *cdnDomainDataSourceModel) setDomainConfig(ctx context.Context, cdnDomain *byteplusCdnClient.DomainSummary, domainConfig *byteplusCdnClient.DomainConfig) (diags diag.Diagnostics) {
+	m.ServiceType = types.StringValue(cdnDomain.ServiceType)
+	m.ServiceRegion = types.StringValue(cdnDomain.ServiceRegion)
+	m.ProjectName = types.StringValue(cdnDomain.Project)
+	m.OriginProtocol = types.StringValue(domainConfig.OriginProtocol)
+	m.OriginHost = stringValueOrNull(domainConfig.OriginHost)
+	m.Origins = flattenCdnDomainOrigins(domainConfig.Origin)
+	m.IPv6 = types.BoolValue(cdnDomain.IPv6)
+	m.LockStatus = types.StringValue(cdnDomain.DomainLock.Status)
+	m.CreateTime = unixTimeValue(cdnDomain.CreateTime)
+	m.UpdateTime = unixTimeValue(cdnDomain.UpdateTime)
+
+	m.Tags, diags = types.MapValueFrom(ctx, types.StringType, cdnTagsMap(cdnDomain.ResourceTags))
+	if diags.HasError() {
+		return
+	}
+
+	https := domainConfig.HTTPS
+	if https == nil {
+		https = &byteplusCdnClient.HTTPS{}
+	}
+	m.Https = &cdnDomainHttpsModel{
+		Enabled:        types.BoolValue(byteplus.BoolValue(https.Switch)),
+		Http2:          types.BoolValue(byteplus.BoolValue(https.HTTP2)),
+		ForcedRedirect: types.BoolValue(https.ForcedRedirect != nil && byteplus.BoolValue(https.ForcedRedirect.EnableForcedRedirect)),
+	}
+	if https.CertInfo != nil {
+		m.Https.CertId = stringValueOrNull(https.CertInfo.CertId)
+		m.Https.CertName = stringValueOrNull(https.CertInfo.CertName)
+		m.Https.CertExpireTime = unixTimeValue(byteplus.Int64Value(https.CertInfo.ExpireTime))
+	}
+	m.Https.TlsVersions, diags = types.ListValueFrom(ctx, types.StringType, https.TlsVersion)
+
+	return
+}
@@ -0,0 +1,303 @@
+package byteplus
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+
+	byteplusCdnClient "github.com/byteplus-sdk/byteplus-sdk-golang/service/cdn"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &cdnDomainsDataSource{}
+	_ datasource.DataSourceWithConfigure = &cdnDomainsDataSource{}
+)
+
+func NewCdnDomainsDataSource() datasource.DataSource {
+	return &cdnDomainsDataSource{}
+}
+
+type cdnDomainsDataSource struct {
+	clients *clientFactory
+}
+
+type cdnDomainsDataSourceModel struct {
+	ClientConfig *clientConfig            `tfsdk:"client_config"`
+	NameRegex    types.String             `tfsdk:"name_regex"`
+	Status       types.String             `tfsdk:"status"`
+	ServiceType  types.String             `tfsdk:"service_type"`
+	ProjectName  types.String             `tfsdk:"project_name"`
+	Tags         types.Map                `tfsdk:"tags"`
+	DomainNames  []string                 `tfsdk:"domain_names"`
+	Domains      []*cdnDomainsDomainModel `tfsdk:"domains"`
+}
+
+type cdnDomainsDomainModel struct {
+	Domain         types.String `tfsdk:"domain_name"`
+	Cname          types.String `tfsdk:"cname"`
+	Status         types.String `tfsdk:"status"`
+	ServiceType    types.String `tfsdk:"service_type"`
+	ServiceRegion  types.String `tfsdk:"service_region"`
+	ProjectName    types.String `tfsdk:"project_name"`
+	OriginProtocol types.String `tfsdk:"origin_protocol"`
+	PrimaryOrigin  types.List   `tfsdk:"primary_origin"`
+	Https          types.Bool   `tfsdk:"https"`
+	IPv6           types.Bool   `tfsdk:"ipv6"`
+	Tags           types.Map    `tfsdk:"tags"`
+	LockStatus     types.String `tfsdk:"lock_status"`
+	CreateTime     types.String `tfsdk:"create_time"`
+	UpdateTime     types.String `tfsdk:"update_time"`
+}
+
+// Metadata returns the data source type name.
+func (d *cdnDomainsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_cdn_domains"
+}
+
+func (d *cdnDomainsDataSource) Schema(_ context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "This data source provides the CDN domains of the current Byteplus user that match the filters.",
+		Attributes: map[string]schema.Attribute{
+			"name_regex": schema.StringAttribute{
+				Description: "A regex to filter the CDN domains by domain name, e.g. `\\.example\\.com$`. " +
+					"Default to match all domains.",
+				Optional: true,
+			},
+			"status": schema.StringAttribute{
+				Description: "Filter the CDN domains by status, valid values are `online`, `offline`, " +
+					"`configuring`, `configure_failed` and `audit_failed`.",
+				Optional: true,
+			},
+			"service_type": schema.StringAttribute{
+				Description: "Filter the CDN domains by service type, valid values are `web`, " +
+					"`download` and `video`.",
+				Optional: true,
+			},
+			"project_name": schema.StringAttribute{
+				Description: "Filter the CDN domains by project.",
+				Optional:    true,
+			},
+			"tags": schema.MapAttribute{
+				Description: "Filter the CDN domains by tags, a domain matches if it has all the tags.",
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+			"domain_names": schema.ListAttribute{
+				Description: "The names of the matched CDN domains.",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+			"domains": schema.ListNestedAttribute{
+				Description: "The matched CDN domains, sorted by domain name.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"domain_name": schema.StringAttribute{
+							Description: "Domain name of CDN domain.",
+							Computed:    true,
+						},
+						"cname": schema.StringAttribute{
+							Description: "Domain CName of CDN domain.",
+							Computed:    true,
+						},
+						"status": schema.StringAttribute{
+							Description: "Status of CDN domain.",
+							Computed:    true,
+						},
+						"service_type": schema.StringAttribute{
+							Description: "The service type of CDN domain.",
+							Computed:    true,
+						},
+						"service_region": schema.StringAttribute{
+							Description: "The service region of CDN domain.",
+							Computed:    true,
+						},
+						"project_name": schema.StringAttribute{
+							Description: "The project of CDN domain.",
+							Computed:    true,
+						},
+						"origin_protocol": schema.StringAttribute{
+							Description: "The protocol to fetch from the origins.",
+							Computed:    true,
+						},
+						"primary_origin": schema.ListAttribute{
+							Description: "The addresses of the primary origins.",
+							Computed:    true,
+							ElementType: types.StringType,
+						},
+						"https": schema.BoolAttribute{
+							Description: "Whether HTTPS is enabled.",
+							Computed:    true,
+						},
+						"ipv6": schema.BoolAttribute{
+							Description: "Whether IPv6 is enabled.",
+							Computed:    true,
+						},
+						"tags": schema.MapAttribute{
+							Description: "The tags of CDN domain.",
+							Computed:    true,
+							ElementType: types.StringType,
+						},
+						"lock_status": schema.StringAttribute{
+							Description: "The lock status of CDN domain, a locked domain cannot be updated.",
+							Computed:    true,
+						},
+						"create_time": schema.StringAttribute{
+							Description: "The creation time of CDN domain in RFC3339 format.",
+							Computed:    true,
+						},
+						"update_time": schema.StringAttribute{
+							Description: "The last update time of CDN domain in RFC3339 format.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+
+		Blocks: map[string]schema.Block{
+			"client_config": dataSourceClientConfigBlock("CDN domains", "list CDN domains"),
+		},
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *cdnDomainsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	d.clients = req.ProviderData.(byteplusClients).clients
+}
+
+func (d *cdnDomainsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var plan cdnDomainsDataSourceModel
+	diags := req.Config.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, clientDiags := d.clients.cdnClient(plan.ClientConfig)
+	resp.Diagnostics.Append(clientDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	validateStringInSlice(&resp.Diagnostics, path.Root("status"), plan.Status, cdnDomainStatuses)
+	validateStringInSlice(&resp.Diagnostics, path.Root("service_type"), plan.ServiceType, cdnServiceTypes)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var nameRegex *regexp.Regexp
+	if nameRegexValue := plan.NameRegex.ValueString(); nameRegexValue != "" {
+		var err error
+		nameRegex, err = regexp.Compile(nameRegexValue)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("name_regex"),
+				"Invalid Name Regex",
+				fmt.Sprintf("The name regex %q cannot be compiled.\n\n%s", nameRegexValue, err.Error()),
+			)
+			return
+		}
+	}
+
+	filterTags := make(map[string]string)
+	if !plan.Tags.IsNull() {
+		resp.Diagnostics.Append(plan.Tags.ElementsAs(ctx, &filterTags, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	cdnDomains, err := listCdnDomains(ctx, client, &byteplusCdnClient.ListCdnDomainsRequest{
+		Project:     stringPtrOrNil(plan.ProjectName),
+		ServiceType: stringPtrOrNil(plan.ServiceType),
+		Status:      stringPtrOrNil(plan.Status),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"[API ERROR] Failed to List CDN Domains.",
+			err.Error(),
+		)
+		return
+	}
+
+	sort.Slice(cdnDomains, func(i, j int) bool {
+		return cdnDomains[i].Domain < cdnDomains[j].Domain
+	})
+
+	state := plan
+	state.DomainNames = []string{}
+	state.Domains = []*cdnDomainsDomainModel{}
+	for _, cdnDomain := range cdnDomains {
+		if nameRegex != nil && !nameRegex.MatchString(cdnDomain.Domain) {
+			continue
+		}
+
+		tags := cdnTagsMap(cdnDomain.ResourceTags)
+		if !containsTags(tags, filterTags) {
+			continue
+		}
+
+		domain, diags := flattenCdnDomainSummary(ctx, cdnDomain, tags)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		state.DomainNames = append(state.DomainNames, cdnDomain.Domain)
+		state.Domains = append(state.Domains, domain)
+	}
+
+	// The client_config block is not recorded in state file.
+	state.ClientConfig = nil
+
+	// Set state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// flattenCdnDomainSummary converts the summary of CDN domain from
+// ListCdnDomains API to the model of the data source.
+func flattenCdnDomainSummary(ctx context.Context, cdnDomain byteplusCdnClient.DomainSummary, tags map[string]string) (domain *cdnDomainsDomainModel, diags diag.Diagnostics) {
+	domain = &cdnDomainsDomainModel{
+		Domain:         types.StringValue(cdnDomain.Domain),
+		Cname:          types.StringValue(cdnDomain.Cname),
+		Status:         types.StringValue(cdnDomain.Status),
+		ServiceType:    types.StringValue(cdnDomain.ServiceType),
+		ServiceRegion:  types.StringValue(cdnDomain.ServiceRegion),
+		ProjectName:    types.StringValue(cdnDomain.Project),
+		OriginProtocol: types.StringValue(cdnDomain.OriginProtocol),
+		Https:          types.BoolValue(cdnDomain.HTTPS),
+		IPv6:           types.BoolValue(cdnDomain.IPv6),
+		LockStatus:     types.StringValue(cdnDomain.DomainLock.Status),
+		CreateTime:     unixTimeValue(cdnDomain.CreateTime),
+		UpdateTime:     unixTimeValue(cdnDomain.UpdateTime),
+	}
+
+	primaryOrigin := cdnDomain.PrimaryOrigin
+	if primaryOrigin == nil {
+		primaryOrigin = []string{}
+	}
+	domain.PrimaryOrigin, diags = types.ListValueFrom(ctx, types.StringType, primaryOrigin)
+	if diags.HasError() {
+		return
+	}
+
+	domain.Tags, diags = types.MapValueFrom(ctx, types.StringType, tags)
+	return
+}
@@ -1,57 +1,373 @@
 package byteplus
 
 import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/byteplus-sdk/byteplus-go-sdk-v2/byteplus"
+	"github.com/byteplus-sdk/byteplus-go-sdk-v2/byteplus/credentials"
+	"github.com/byteplus-sdk/byteplus-go-sdk-v2/byteplus/session"
+	byteplusIamClient "github.com/byteplus-sdk/byteplus-go-sdk-v2/service/iam"
 	byteplusBaseClient "github.com/byteplus-sdk/byteplus-sdk-golang/base"
+	byteplusCdnClient "github.com/byteplus-sdk/byteplus-sdk-golang/service/cdn"
+	byteplusStsClient "github.com/byteplus-sdk/byteplus-sdk-golang/service/sts"
 	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
-func initNewClient(providerConfig *byteplusBaseClient.Client, planConfig *clientConfig) (initClient bool, clientConfig *byteplusBaseClient.Credentials, diag diag.Diagnostics) {
-	initClient = false
-	clientConfig = &byteplusBaseClient.Credentials{}
-	region := planConfig.Region.ValueString()
-	accessKey := planConfig.AccessKey.ValueString()
-	secretKey := planConfig.SecretKey.ValueString()
-
-	if region != "" || accessKey != "" || secretKey != "" {
-		initClient = true
-	}
-
-	if initClient {
-		if region == "" {
-			region = providerConfig.ServiceInfo.Credentials.Region
-		}
-		if accessKey == "" {
-			clientAccessKey := providerConfig.ServiceInfo.Credentials.AccessKeyID
-			if clientAccessKey == "" {
-				diag.AddError(
-					"Failed to retrieve client Access Key.",
-					"This is an error in provider, please contact the provider developers.",
-				)
-			} else {
-				accessKey = clientAccessKey
-			}
-		}
-		if secretKey == "" {
-			clientSecretKey := providerConfig.ServiceInfo.Credentials.SecretAccessKey
-			if clientSecretKey == "" {
-				diag.AddError(
-					"Failed to retrieve client Secret Key.",
-					"This is an error in provider, please contact the provider developers.",
-				)
-			} else {
-				secretKey = clientSecretKey
-			}
-		}
-		if diag.HasError() {
-			return
+// newIamClient creates an IAM client with the credentials and endpoint.
+//
+// Parameters:
+//   - clientCredentials: The credentials of the client.
+//   - region: The region of the client.
+//   - endpoint: The custom endpoint of IAM API, empty to use the public endpoint.
+//   - httpClient: The HTTP client with the proxy, TLS and timeout settings, nil to use the default.
+//
+// Returns:
+//   - client: The IAM client.
+//   - err: Error of creating the session of the client.
+func newIamClient(clientCredentials *credentials.Credentials, region, endpoint string, httpClient *http.Client) (client *byteplusIamClient.IAM, err error) {
+	config := byteplus.NewConfig().
+		WithCredentials(clientCredentials).
+		WithRegion(region)
+	if endpoint != "" {
+		config.WithEndpoint(endpoint)
+	}
+	if httpClient != nil {
+		config.WithHTTPClient(httpClient)
+	}
+
+	sess, err := session.NewSession(config)
+	if err != nil {
+		return nil, err
+	}
+
+	return byteplusIamClient.New(sess), nil
+}
+
+// newCdnClient creates a CDN client with the credentials and endpoint.
+//
+// Parameters:
+//   - clientCredentials: The credentials and region of the client.
+//   - endpoint: The custom endpoint of CDN API, empty to use the public endpoint.
+//   - httpClient: The HTTP client with the proxy, TLS and timeout settings, nil to use the default.
+//
+// Returns:
+//   - The CDN client.
+func newCdnClient(clientCredentials byteplusBaseClient.Credentials, endpoint string, httpClient *http.Client) *byteplusCdnClient.CDN {
+	client := byteplusCdnClient.NewInstance()
+	client.Client.SetCredential(clientCredentials)
+
+	// The SDK sends CDN requests in plain HTTP by default.
+	client.Client.SetScheme("https")
+
+	scheme, host := splitEndpoint(endpoint)
+	client.Client.SetScheme(scheme)
+	client.Client.SetHost(host)
+
+	if httpClient != nil {
+		client.Client.Client = *httpClient
+		client.Client.SetTimeout(httpClient.Timeout)
+	}
+
+	return client
+}
+
+// The APIs of STS called with the client created by newStsClient. The SDK
+// only supports AssumeRole, which is called here too, so it is sent with the
+// HTTP client and the endpoint of the provider.
+var stsApiInfoList = map[string]*byteplusBaseClient.ApiInfo{
+	stsActionGetCallerIdentity: {
+		Method: http.MethodGet,
+		Path:   "/",
+		Query: url.Values{
+			"Action":  []string{stsActionGetCallerIdentity},
+			"Version": []string{byteplusStsClient.ServiceVersion20180101},
+		},
+	},
+	stsActionAssumeRole: {
+		Method: http.MethodGet,
+		Path:   "/",
+		Query: url.Values{
+			"Action":  []string{stsActionAssumeRole},
+			"Version": []string{byteplusStsClient.ServiceVersion20180101},
+		},
+	},
+}
+
+const (
+	stsActionGetCallerIdentity = "GetCallerIdentity"
+	stsActionAssumeRole        = "AssumeRole"
+)
+
+// newStsClient creates an STS client with the credentials and endpoint.
+//
+// Parameters:
+//   - clientCredentials: The credentials and region of the client.
+//   - endpoint: The custom endpoint of STS API, empty to use the public endpoint.
+//   - httpClient: The HTTP client with the proxy, TLS and timeout settings, nil to use the default.
+//
+// Returns:
+//   - The STS client.
+func newStsClient(clientCredentials byteplusBaseClient.Credentials, endpoint string, httpClient *http.Client) *byteplusBaseClient.Client {
+	client := byteplusBaseClient.NewClient(byteplusStsClient.ServiceInfo, stsApiInfoList)
+	client.ServiceInfo.Credentials.Service = byteplusStsClient.ServiceName
+	client.SetCredential(clientCredentials)
+	client.SetScheme("https")
+
+	if endpoint != "" {
+		scheme, host := splitEndpoint(endpoint)
+		if scheme != "" {
+			client.SetScheme(scheme)
 		}
+		client.SetHost(host)
+	}
+
+	if httpClient != nil {
+		client.Client = *httpClient
+		client.SetTimeout(httpClient.Timeout)
+	}
 
-		clientConfig = &byteplusBaseClient.Credentials{
-			AccessKeyID:     accessKey,
-			SecretAccessKey: secretKey,
-			Region:          region,
+	return client
+}
+
+// newHttpClient creates the HTTP client shared by all Byteplus API clients.
+//
+// Parameters:
+//   - proxy: The URL of the HTTP proxy, empty to use the proxy in HTTP_PROXY and HTTPS_PROXY environment variables.
+//   - caBundle: Path of the PEM file with the additional CA certificates to trust.
+//   - insecure: Whether to skip the TLS certificate verification.
+//   - timeout: The timeout of each request, zero for no timeout.
+//
+// Returns:
+//   - httpClient: The HTTP client.
+//   - err: Error of parsing the proxy URL or loading the CA bundle.
+func newHttpClient(proxy, caBundle string, insecure bool, timeout time.Duration) (httpClient *http.Client, err error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.MaxIdleConns = 1000
+	transport.MaxIdleConnsPerHost = 100
+
+	if proxy != "" {
+		proxyUrl, err := url.Parse(proxy)
+		if err != nil {
+			return nil, fmt.Errorf("invalid HTTP proxy URL %s: %w", proxy, err)
 		}
+		transport.Proxy = http.ProxyURL(proxyUrl)
+	}
+
+	tlsConfig := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		InsecureSkipVerify: insecure,
+	}
+
+	if caBundle != "" {
+		caBundle, err = expandHomeDir(caBundle)
+		if err != nil {
+			return nil, err
+		}
+
+		pem, err := os.ReadFile(caBundle)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CA bundle %s: %w", caBundle, err)
+		}
+
+		certPool, err := x509.SystemCertPool()
+		if err != nil {
+			certPool = x509.NewCertPool()
+		}
+		if !certPool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no PEM encoded certificate found in CA bundle %s", caBundle)
+		}
+		tlsConfig.RootCAs = certPool
+	}
+	transport.TLSClientConfig = tlsConfig
+
+	return &http.Client{
+		Transport: transport,
+		Timeout:   timeout,
+	}, nil
+}
+
+// splitEndpoint splits the endpoint into scheme and host, the scheme is empty
+// if the endpoint is a bare host.
+func splitEndpoint(endpoint string) (scheme, host string) {
+	if endpointScheme, endpointHost, ok := strings.Cut(endpoint, "://"); ok {
+		return endpointScheme, strings.TrimSuffix(endpointHost, "/")
+	}
+
+	return "", strings.TrimSuffix(endpoint, "/")
+}
+
+// validateStringInSlice adds an attribute error if the known and non-null
+// value is not one of the valid values.
+func validateStringInSlice(diags *diag.Diagnostics, attributePath path.Path, value types.String, validValues []string) {
+	if value.IsNull() || value.IsUnknown() {
+		return
+	}
+
+	for _, validValue := range validValues {
+		if value.ValueString() == validValue {
+			return
+		}
+	}
+
+	diags.AddAttributeError(
+		attributePath,
+		"Invalid Attribute Value",
+		fmt.Sprintf("The value must be one of %s, got %q.", strings.Join(validValues, ", "), value.ValueString()),
+	)
+}
+
+// stringPtrOrNil returns the pointer of the string value, or nil if the value
+// is null, unknown or empty.
+func stringPtrOrNil(value types.String) *string {
+	if value.IsNull() || value.IsUnknown() || value.ValueString() == "" {
+		return nil
+	}
+
+	return byteplus.String(value.ValueString())
+}
+
+// stringValueOrNull returns the string value of the pointer, or null if the
+// pointer is nil or empty.
+func stringValueOrNull(value *string) types.String {
+	if value == nil || *value == "" {
+		return types.StringNull()
+	}
+
+	return types.StringValue(*value)
+}
+
+// stringValue returns the string of the pointer, or an empty string if the
+// pointer is nil.
+func stringValue(value *string) string {
+	return byteplus.StringValue(value)
+}
+
+// int64Value parses the integer in the string pointer, or returns 0 if the
+// pointer is nil or not an integer.
+func int64Value(value *string) int64 {
+	i, _ := strconv.ParseInt(byteplus.StringValue(value), 10, 64)
+	return i
+}
+
+// unixTimeValue returns the Unix time in seconds in RFC3339 format, or null if
+// the time is not set.
+func unixTimeValue(unixTime int64) types.String {
+	if unixTime <= 0 {
+		return types.StringNull()
+	}
+
+	return types.StringValue(time.Unix(unixTime, 0).UTC().Format(time.RFC3339))
+}
+
+// chunkStrings splits the values into chunks of at most the size.
+func chunkStrings(values []string, size int) (chunks [][]string) {
+	for size < len(values) {
+		chunks = append(chunks, values[:size])
+		values = values[size:]
+	}
+	if len(values) > 0 {
+		chunks = append(chunks, values)
 	}
 
 	return
 }
+
+// uniqueStrings returns the values without duplicates in their original order.
+func uniqueStrings(values []string) []string {
+	seen := make(map[string]bool, len(values))
+	unique := make([]string, 0, len(values))
+	for _, value := range values {
+		if !seen[value] {
+			seen[value] = true
+			unique = append(unique, value)
+		}
+	}
+
+	return unique
+}
+
+// parseWaitTimeout parses the duration of a wait timeout attribute, e.g. `10m`.
+//
+// Parameters:
+//   - diags: The diagnostics to append the error of an invalid duration.
+//   - attributePath: The path of the attribute.
+//   - value: The value of the attribute.
+//   - defaultTimeout: The timeout if the attribute is not set.
+//
+// Returns:
+//   - timeout: The wait timeout.
+func parseWaitTimeout(diags *diag.Diagnostics, attributePath path.Path, value types.String, defaultTimeout time.Duration) (timeout time.Duration) {
+	if value.IsNull() || value.IsUnknown() || value.ValueString() == "" {
+		return defaultTimeout
+	}
+
+	timeout, err := time.ParseDuration(value.ValueString())
+	if err != nil || timeout <= 0 {
+		diags.AddAttributeError(
+			attributePath,
+			"Invalid Wait Timeout",
+			fmt.Sprintf("The wait timeout must be a positive duration such as 10m, got %q.", value.ValueString()),
+		)
+		return defaultTimeout
+	}
+
+	return timeout
+}
+
+// validateContentUrls validates the URLs to refresh or preload are absolute
+// HTTP or HTTPS URLs, and the URLs of directories end with `/`.
+//
+// Parameters:
+//   - diags: The diagnostics to append the errors of the invalid URLs.
+//   - attributePath: The path of the attribute.
+//   - urls: The value of the attribute.
+//   - isDir: Whether the URLs are directories.
+func validateContentUrls(diags *diag.Diagnostics, attributePath path.Path, urls types.List, isDir bool) {
+	if urls.IsNull() || urls.IsUnknown() {
+		return
+	}
+
+	if len(urls.Elements()) == 0 {
+		diags.AddAttributeError(
+			attributePath,
+			"Missing URLs",
+			"At least one URL is required.",
+		)
+		return
+	}
+
+	for i, element := range urls.Elements() {
+		value, ok := element.(types.String)
+		if !ok || value.IsNull() || value.IsUnknown() {
+			continue
+		}
+
+		contentUrl, err := url.Parse(value.ValueString())
+		if err != nil || (contentUrl.Scheme != "http" && contentUrl.Scheme != "https") || contentUrl.Host == "" {
+			diags.AddAttributeError(
+				attributePath.AtListIndex(i),
+				"Invalid URL",
+				fmt.Sprintf("The URL must be an absolute URL starting with http:// or https://, got %q.", value.ValueString()),
+			)
+			continue
+		}
+
+		if isDir && !strings.HasSuffix(contentUrl.Path, "/") {
+			diags.AddAttributeError(
+				attributePath.AtListIndex(i),
+				"Invalid Directory URL",
+				fmt.Sprintf("The URL of a directory must end with /, got %q.", value.ValueString()),
+			)
+		}
+	}
+}
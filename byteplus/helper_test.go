@@ -0,0 +1,111 @@
+package byteplus
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	byteplusBaseClient "github.com/byteplus-sdk/byteplus-sdk-golang/base"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestSplitEndpoint(t *testing.T) {
+	tests := []struct {
+		endpoint   string
+		wantScheme string
+		wantHost   string
+	}{
+		{endpoint: "cdn.byteplusapi.com", wantScheme: "", wantHost: "cdn.byteplusapi.com"},
+		{endpoint: "https://cdn.byteplusapi.com", wantScheme: "https", wantHost: "cdn.byteplusapi.com"},
+		{endpoint: "http://localhost:8080/", wantScheme: "http", wantHost: "localhost:8080"},
+		{endpoint: "cdn.internal:8443/", wantScheme: "", wantHost: "cdn.internal:8443"},
+		{endpoint: "", wantScheme: "", wantHost: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.endpoint, func(t *testing.T) {
+			scheme, host := splitEndpoint(tt.endpoint)
+			if scheme != tt.wantScheme || host != tt.wantHost {
+				t.Errorf("splitEndpoint(%q) = (%q, %q), want (%q, %q)",
+					tt.endpoint, scheme, host, tt.wantScheme, tt.wantHost)
+			}
+		})
+	}
+}
+
+func TestNewCdnClientEndpoint(t *testing.T) {
+	tests := []struct {
+		endpoint   string
+		wantScheme string
+		wantHost   string
+	}{
+		{endpoint: "", wantScheme: "https", wantHost: "open.byteplusapi.com"},
+		{endpoint: "cdn.internal", wantScheme: "https", wantHost: "cdn.internal"},
+		{endpoint: "http://localhost:8080", wantScheme: "http", wantHost: "localhost:8080"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.endpoint, func(t *testing.T) {
+			client := newCdnClient(byteplusBaseClient.Credentials{}, tt.endpoint, nil)
+			if scheme := client.Client.ServiceInfo.Scheme; scheme != tt.wantScheme {
+				t.Errorf("scheme = %q, want %q", scheme, tt.wantScheme)
+			}
+			if host := client.Client.ServiceInfo.Host; host != tt.wantHost {
+				t.Errorf("host = %q, want %q", host, tt.wantHost)
+			}
+		})
+	}
+}
+
+func TestChunkStrings(t *testing.T) {
+	tests := []struct {
+		name   string
+		values []string
+		size   int
+		want   [][]string
+	}{
+		{name: "empty", values: nil, size: 2, want: nil},
+		{name: "smaller than size", values: []string{"a"}, size: 2, want: [][]string{{"a"}}},
+		{name: "equal to size", values: []string{"a", "b"}, size: 2, want: [][]string{{"a", "b"}}},
+		{name: "last chunk partial", values: []string{"a", "b", "c", "d", "e"}, size: 2, want: [][]string{{"a", "b"}, {"c", "d"}, {"e"}}},
+		{name: "size of one", values: []string{"a", "b"}, size: 1, want: [][]string{{"a"}, {"b"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := chunkStrings(tt.values, tt.size); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("chunkStrings(%v, %d) = %v, want %v", tt.values, tt.size, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateContentUrls(t *testing.T) {
+	tests := []struct {
+		name       string
+		urls       []string
+		isDir      bool
+		wantErrors int
+	}{
+		{name: "files", urls: []string{"https://www.example.com/a.js", "http://www.example.com/b.css?v=1"}},
+		{name: "directories", urls: []string{"https://www.example.com/", "https://www.example.com/static/"}, isDir: true},
+		{name: "directory without trailing slash", urls: []string{"https://www.example.com/static"}, isDir: true, wantErrors: 1},
+		{name: "relative and unsupported scheme", urls: []string{"/a.js", "ftp://www.example.com/a.js"}, wantErrors: 2},
+		{name: "no URLs", urls: []string{}, wantErrors: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			urls, diags := types.ListValueFrom(context.Background(), types.StringType, tt.urls)
+			if diags.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", diags)
+			}
+
+			validateContentUrls(&diags, path.Root("urls"), urls, tt.isDir)
+			if got := diags.ErrorsCount(); got != tt.wantErrors {
+				t.Errorf("errors = %d, want %d: %v", got, tt.wantErrors, diags)
+			}
+		})
+	}
+}
@@ -0,0 +1,134 @@
+package byteplus
+
+import (
+	"context"
+	"time"
+
+	"github.com/byteplus-sdk/byteplus-go-sdk-v2/byteplus"
+	"github.com/byteplus-sdk/byteplus-go-sdk-v2/byteplus/bytepluserr"
+	byteplusIamClient "github.com/byteplus-sdk/byteplus-go-sdk-v2/service/iam"
+	byteplusBaseClient "github.com/byteplus-sdk/byteplus-sdk-golang/base"
+	byteplusCdnClient "github.com/byteplus-sdk/byteplus-sdk-golang/service/cdn"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const (
+	// Prefix of the environment variables that set the log level of each
+	// subsystem, e.g. TF_LOG_PROVIDER_ST_BYTEPLUS_CDN=TRACE.
+	logLevelEnvPrefix = "TF_LOG_PROVIDER_ST_BYTEPLUS"
+
+	logSubsystemCdn = "cdn"
+	logSubsystemIam = "iam"
+	logSubsystemSts = "sts"
+)
+
+// Field keys whose values must never be written to the log output.
+var logSensitiveFieldKeys = []string{
+	"access_key",
+	"secret_key",
+	"session_token",
+}
+
+// logApiCall executes a single BytePlus SDK call and records it in the log of
+// the subsystem with its duration, retry attempt and error code. Credentials of
+// the client are masked from messages and field values before anything is
+// written, so it is safe to run with TF_LOG=DEBUG.
+//
+// Parameters:
+//   - ctx: Context.
+//   - subsystem: The log subsystem of the BytePlus service.
+//   - action: The API action name.
+//   - region: The region of the client.
+//   - attempt: The attempt number of the call within its backoff retry, starting from 1.
+//   - secrets: The credentials of the client to be masked.
+//   - call: The SDK call to execute.
+//
+// Returns:
+//   - err: The error returned from the SDK call.
+func logApiCall(ctx context.Context, subsystem, action, region string, attempt int, secrets []string, call func() error) (err error) {
+	ctx = tflog.NewSubsystem(ctx, subsystem, tflog.WithLevelFromEnv(logLevelEnvPrefix, subsystem), tflog.WithRootFields())
+	ctx = tflog.SubsystemMaskFieldValuesWithFieldKeys(ctx, subsystem, logSensitiveFieldKeys...)
+
+	var maskedStrings []string
+	for _, secret := range secrets {
+		if secret != "" {
+			maskedStrings = append(maskedStrings, secret)
+		}
+	}
+	ctx = tflog.SubsystemMaskAllFieldValuesStrings(ctx, subsystem, maskedStrings...)
+	ctx = tflog.SubsystemMaskMessageStrings(ctx, subsystem, maskedStrings...)
+
+	fields := map[string]interface{}{
+		"action":  action,
+		"region":  region,
+		"attempt": attempt,
+	}
+	tflog.SubsystemTrace(ctx, subsystem, "Sending BytePlus API request", fields)
+
+	start := time.Now()
+	err = call()
+	fields["duration_ms"] = time.Since(start).Milliseconds()
+
+	if err != nil {
+		fields["error_code"] = apiErrorCode(err)
+		fields["error"] = err.Error()
+		tflog.SubsystemDebug(ctx, subsystem, "BytePlus API request failed", fields)
+		return
+	}
+
+	tflog.SubsystemDebug(ctx, subsystem, "BytePlus API request succeeded", fields)
+	return
+}
+
+// logCdnApiCall executes and logs a call made with the CDN client.
+func logCdnApiCall(ctx context.Context, client *byteplusCdnClient.CDN, action string, attempt int, call func() error) error {
+	credentials := client.Client.ServiceInfo.Credentials
+	secrets := []string{
+		credentials.AccessKeyID,
+		credentials.SecretAccessKey,
+		credentials.SessionToken,
+	}
+
+	return logApiCall(ctx, logSubsystemCdn, action, credentials.Region, attempt, secrets, call)
+}
+
+// logIamApiCall executes and logs a call made with the IAM client.
+func logIamApiCall(ctx context.Context, client *byteplusIamClient.IAM, action string, attempt int, call func() error) error {
+	var secrets []string
+	if client.Config.Credentials != nil {
+		if credentials, err := client.Config.Credentials.Get(); err == nil {
+			secrets = []string{
+				credentials.AccessKeyID,
+				credentials.SecretAccessKey,
+				credentials.SessionToken,
+			}
+		}
+	}
+
+	return logApiCall(ctx, logSubsystemIam, action, byteplus.StringValue(client.Config.Region), attempt, secrets, call)
+}
+
+// logStsApiCall executes and logs a call made with the STS client.
+func logStsApiCall(ctx context.Context, client *byteplusBaseClient.Client, action string, attempt int, call func() error) error {
+	credentials := client.ServiceInfo.Credentials
+	secrets := []string{
+		credentials.AccessKeyID,
+		credentials.SecretAccessKey,
+		credentials.SessionToken,
+	}
+
+	return logApiCall(ctx, logSubsystemSts, action, credentials.Region, attempt, secrets, call)
+}
+
+// apiErrorCode returns the error code of errors returned from both BytePlus
+// SDKs, or an empty string if the error does not carry one.
+func apiErrorCode(err error) string {
+	switch byteErr := err.(type) {
+	case byteplusCdnClient.CDNError:
+		return byteErr.Code
+	case bytepluserr.Error:
+		return byteErr.Code()
+	default:
+		return ""
+	}
+}
@@ -3,26 +3,34 @@ package byteplus
 import (
 	"context"
 	"os"
+	"strings"
+	"time"
 
-
-	"github.com/byteplus-sdk/byteplus-go-sdk-v2/byteplus"
 	"github.com/byteplus-sdk/byteplus-go-sdk-v2/byteplus/credentials"
-	"github.com/byteplus-sdk/byteplus-go-sdk-v2/byteplus/session"
-	byteplusIamClient "github.com/byteplus-sdk/byteplus-go-sdk-v2/service/iam"
-	byteplusBaseClient "github.com/byteplus-sdk/byteplus-sdk-golang/base"
-	byteplusCdnClient "github.com/byteplus-sdk/byteplus-sdk-golang/service/cdn"
+	"github.com/byteplus-sdk/byteplus-go-sdk-v2/byteplus/credentials/processcreds"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 // Wrapper of Byteplus client
 type byteplusClients struct {
-	cdnClient *byteplusCdnClient.CDN
-	iamClient *byteplusIamClient.IAM
+	// The factory of the clients of each set of credentials, shared by all
+	// resources and data sources.
+	clients *clientFactory
+
+	// The identity of the credentials of the provider, nil if the
+	// credentials validation is skipped.
+	identity *callerIdentity
+
+	// The tags to add to every taggable resource, and the project to assign
+	// every resource that supports projects to.
+	defaultTags        map[string]string
+	defaultProjectName string
 }
 
 // Ensure the implementation satisfies the expected interfaces.
@@ -40,9 +48,22 @@ type byteplusProvider struct{}
 
 // ByteplusProviderModel maps provider schema data to a Go type.
 type byteplusProviderModel struct {
-	Region    types.String `tfsdk:"region"`
-	AccessKey types.String `tfsdk:"access_key"`
-	SecretKey types.String `tfsdk:"secret_key"`
+	Region                 types.String      `tfsdk:"region"`
+	AccessKey              types.String      `tfsdk:"access_key"`
+	SecretKey              types.String      `tfsdk:"secret_key"`
+	SessionToken           types.String      `tfsdk:"session_token"`
+	CredentialProcess      types.String      `tfsdk:"credential_process"`
+	Profile                types.String      `tfsdk:"profile"`
+	SharedCredentialsFiles types.List        `tfsdk:"shared_credentials_files"`
+	AssumeRole             *assumeRoleConfig `tfsdk:"assume_role"`
+	HttpProxy              types.String      `tfsdk:"http_proxy"`
+	CaBundle               types.String      `tfsdk:"ca_bundle"`
+	Insecure               types.Bool        `tfsdk:"insecure"`
+	RequestTimeout         types.Int64       `tfsdk:"request_timeout"`
+	SkipCredsValidation    types.Bool        `tfsdk:"skip_credentials_validation"`
+	DefaultTags            types.Map         `tfsdk:"default_tags"`
+	DefaultProjectName     types.String      `tfsdk:"default_project_name"`
+	Endpoints              *endpointsConfig  `tfsdk:"endpoints"`
 }
 
 // Metadata returns the provider type name.
@@ -54,7 +75,12 @@ func (p *byteplusProvider) Metadata(_ context.Context, _ provider.MetadataReques
 func (p *byteplusProvider) Schema(_ context.Context, _ provider.SchemaRequest, resp *provider.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Description: "The Byteplus provider is used to interact with the many resources supported by Byteplus." +
-			"The provider needs to be configured with the proper credentials before it can be used.",
+			"The provider needs to be configured with the proper credentials before it can be used.\n\n" +
+			"Credentials are looked up in the following order: `access_key` and `secret_key` in the provider " +
+			"configuration, `credential_process` in the provider configuration, the profile set in `profile` " +
+			"of the provider configuration, the BYTEPLUS_ACCESS_KEY " +
+			"and BYTEPLUS_SECRET_KEY environment variables, and finally the profile set in BYTEPLUS_PROFILE " +
+			"environment variable (or `default`) of the shared credentials files.",
 		Attributes: map[string]schema.Attribute{
 			"region": schema.StringAttribute{
 				Description: "Region for Byteplus API. May also be provided via BYTEPLUS_REGION environment variable.",
@@ -69,6 +95,116 @@ func (p *byteplusProvider) Schema(_ context.Context, _ provider.SchemaRequest, r
 				Optional:    true,
 				Sensitive:   true,
 			},
+			"session_token": schema.StringAttribute{
+				Description: "Session token of the temporary credentials for Byteplus API, e.g. credentials issued by STS. " +
+					"May also be provided via BYTEPLUS_SESSION_TOKEN environment variable, which is only used " +
+					"together with the keys in environment variables.",
+				Optional:  true,
+				Sensitive: true,
+			},
+			"credential_process": schema.StringAttribute{
+				Description: "The command to run to retrieve the credentials for Byteplus API. The command must " +
+					"print a JSON with Version 1, AccessKeyId, SecretAccessKey, and optionally SessionToken and " +
+					"Expiration (RFC3339) to stdout. The command is run again when the credentials expire.",
+				Optional: true,
+			},
+			"profile": schema.StringAttribute{
+				Description: "The profile in the shared credentials files to load the credentials and region from. " +
+					"May also be provided via BYTEPLUS_PROFILE environment variable. Default to `default`.",
+				Optional: true,
+			},
+			"shared_credentials_files": schema.ListAttribute{
+				Description: "List of paths to the shared credentials files, the first file that contains the " +
+					"profile will be used. May also be provided via BYTEPLUS_SHARED_CREDENTIALS_FILE environment " +
+					"variable. Default to `~/.byteplus/credentials`.",
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+			"skip_credentials_validation": schema.BoolAttribute{
+				Description: "Skip the validation of the credentials at provider configure time, which calls " +
+					"STS GetCallerIdentity API once to detect invalid or revoked keys and resolve the account ID. " +
+					"When skipped, the credentials are not retrieved until the first API call. Default to false.",
+				Optional: true,
+			},
+			"http_proxy": schema.StringAttribute{
+				Description: "The URL of the HTTP proxy for Byteplus API, e.g. http://proxy.example.com:3128. " +
+					"Default to use the proxy in HTTP_PROXY and HTTPS_PROXY environment variables.",
+				Optional: true,
+			},
+			"ca_bundle": schema.StringAttribute{
+				Description: "Path of the PEM file with the additional CA certificates to trust, e.g. the CA of " +
+					"a TLS intercepting proxy. May also be provided via BYTEPLUS_CA_BUNDLE environment variable.",
+				Optional: true,
+			},
+			"insecure": schema.BoolAttribute{
+				Description: "Skip the TLS certificate verification of Byteplus API. Only use it for testing. " +
+					"Default to false.",
+				Optional: true,
+			},
+			"request_timeout": schema.Int64Attribute{
+				Description: "The timeout of each Byteplus API request in seconds. Default to no timeout other " +
+					"than the timeout of the SDK.",
+				Optional: true,
+			},
+			"default_tags": schema.MapAttribute{
+				Description: "Tags to add to every taggable resource of the provider. The tags of a resource " +
+					"override the default tags with the same key, the merged tags are shown in `tags_all` " +
+					"of the resource.",
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+			"default_project_name": schema.StringAttribute{
+				Description: "The project to assign every resource of the provider that supports projects to, " +
+					"unless the resource sets its own `project_name`.",
+				Optional: true,
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"endpoints": schema.SingleNestedBlock{
+				Description: "Custom endpoints of Byteplus services, e.g. a private endpoint, an internal proxy or " +
+					"a local mock for testing. The endpoint is either a host or `scheme://host`. The endpoints are " +
+					"also used by the clients created from `client_config` blocks.",
+				Attributes: map[string]schema.Attribute{
+					"cdn": schema.StringAttribute{
+						Description: "Custom endpoint of CDN API. May also be provided via BYTEPLUS_CDN_ENDPOINT " +
+							"environment variable.",
+						Optional: true,
+					},
+					"iam": schema.StringAttribute{
+						Description: "Custom endpoint of IAM API. May also be provided via BYTEPLUS_IAM_ENDPOINT " +
+							"environment variable.",
+						Optional: true,
+					},
+					"sts": schema.StringAttribute{
+						Description: "Custom endpoint of STS API, used to assume role and validate the credentials. " +
+							"May also be provided via BYTEPLUS_STS_ENDPOINT environment variable.",
+						Optional: true,
+					},
+				},
+			},
+			"assume_role": schema.SingleNestedBlock{
+				Description: "Assume a role through STS with the credentials above, the temporary credentials of " +
+					"the role are used by all the Byteplus API clients and renewed before they expire.",
+				Attributes: map[string]schema.Attribute{
+					"role_trn": schema.StringAttribute{
+						Description: "The TRN of the role to assume, e.g. trn:iam::2100000000:role/terraform.",
+						Optional:    true,
+					},
+					"session_name": schema.StringAttribute{
+						Description: "The session name of the assumed role. Default to `terraform-provider-st-byteplus`.",
+						Optional:    true,
+					},
+					"duration_seconds": schema.Int64Attribute{
+						Description: "The duration of the assumed role session in seconds, between 900 and 43200. " +
+							"Default to 3600.",
+						Optional: true,
+					},
+					"policy": schema.StringAttribute{
+						Description: "The policy in JSON to further restrict the permissions of the assumed role session.",
+						Optional:    true,
+					},
+				},
+			},
 		},
 	}
 }
@@ -110,13 +246,50 @@ func (p *byteplusProvider) Configure(ctx context.Context, req provider.Configure
 		)
 	}
 
+	if config.SessionToken.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("session_token"),
+			"Unknown Byteplus session token",
+			"The provider cannot create the Byteplus API client as there is an unknown configuration value for the"+
+				"Byteplus session token. Set the value statically in the configuration, or use the BYTEPLUS_SESSION_TOKEN environment variable.",
+		)
+	}
+
+	if config.CredentialProcess.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("credential_process"),
+			"Unknown Byteplus credential process",
+			"The provider cannot create the Byteplus API client as there is an unknown configuration value for the"+
+				"Byteplus credential process. Set the value statically in the configuration.",
+		)
+	}
+
+	if config.Profile.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("profile"),
+			"Unknown Byteplus profile",
+			"The provider cannot create the Byteplus API client as there is an unknown configuration value for the"+
+				"Byteplus profile. Set the value statically in the configuration, or use the BYTEPLUS_PROFILE environment variable.",
+		)
+	}
+
+	if config.SharedCredentialsFiles.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("shared_credentials_files"),
+			"Unknown Byteplus shared credentials files",
+			"The provider cannot create the Byteplus API client as there is an unknown configuration value for the"+
+				"Byteplus shared credentials files. Set the value statically in the configuration, or use the "+
+				"BYTEPLUS_SHARED_CREDENTIALS_FILE environment variable.",
+		)
+	}
+
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
 	// Default values to environment variables, but override with Terraform
 	// configuration value if set.
-	var region, accessKey, secretKey string
+	var region, accessKey, secretKey, sessionToken, credentialProcess, profile string
 
 	if !config.Region.IsNull() {
 		region = config.Region.ValueString()
@@ -124,16 +297,87 @@ func (p *byteplusProvider) Configure(ctx context.Context, req provider.Configure
 		region = os.Getenv("BYTEPLUS_REGION")
 	}
 
-	if !config.AccessKey.IsNull() {
-		accessKey = config.AccessKey.ValueString()
-	} else {
-		accessKey = os.Getenv("BYTEPLUS_ACCESS_KEY")
+	accessKey = config.AccessKey.ValueString()
+	secretKey = config.SecretKey.ValueString()
+	sessionToken = config.SessionToken.ValueString()
+
+	// The keys configured in the provider takes precedence over the
+	// credential process.
+	if accessKey == "" && secretKey == "" {
+		credentialProcess = config.CredentialProcess.ValueString()
 	}
 
-	if !config.SecretKey.IsNull() {
-		secretKey = config.SecretKey.ValueString()
+	// A credential process or profile configured in the provider takes
+	// precedence over the keys in environment variables.
+	if config.Profile.IsNull() && credentialProcess == "" {
+		profile = os.Getenv("BYTEPLUS_PROFILE")
+
+		if config.AccessKey.IsNull() {
+			accessKey = os.Getenv("BYTEPLUS_ACCESS_KEY")
+		}
+		if config.SecretKey.IsNull() {
+			secretKey = os.Getenv("BYTEPLUS_SECRET_KEY")
+		}
+		// The session token in environment variables belongs to the keys in
+		// environment variables, it must not be attached to the keys
+		// configured in the provider.
+		if config.SessionToken.IsNull() && config.AccessKey.IsNull() && config.SecretKey.IsNull() {
+			sessionToken = os.Getenv("BYTEPLUS_SESSION_TOKEN")
+		}
 	} else {
-		secretKey = os.Getenv("BYTEPLUS_SECRET_KEY")
+		profile = config.Profile.ValueString()
+	}
+
+	// Fallback to the shared credentials files when no keys are provided.
+	if accessKey == "" && secretKey == "" && credentialProcess == "" {
+		var sharedCredentialsFiles []string
+		if !config.SharedCredentialsFiles.IsNull() {
+			diags = config.SharedCredentialsFiles.ElementsAs(ctx, &sharedCredentialsFiles, false)
+			resp.Diagnostics.Append(diags...)
+			if resp.Diagnostics.HasError() {
+				return
+			}
+		} else {
+			sharedCredentialsFiles = defaultSharedCredentialsFiles()
+		}
+
+		profileRequired := profile != ""
+		if !profileRequired {
+			profile = defaultSharedCredentialsProfile
+		}
+
+		sharedProfile, err := loadSharedCredentialsProfile(sharedCredentialsFiles, profile)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("shared_credentials_files"),
+				"Unable to Load Byteplus Shared Credentials File",
+				"The provider cannot create the Byteplus API client as the shared "+
+					"credentials file cannot be loaded.\n\n"+err.Error(),
+			)
+			return
+		}
+
+		switch {
+		case sharedProfile != nil:
+			accessKey = sharedProfile.AccessKey
+			secretKey = sharedProfile.SecretKey
+			sessionToken = sharedProfile.SessionToken
+			if accessKey == "" && secretKey == "" {
+				credentialProcess = sharedProfile.CredentialProcess
+			}
+			if region == "" {
+				region = sharedProfile.Region
+			}
+		case profileRequired:
+			resp.Diagnostics.AddAttributeError(
+				path.Root("profile"),
+				"Byteplus Profile Not Found",
+				"The provider cannot create the Byteplus API client as the profile "+
+					profile+" is not found in the shared credentials files: "+
+					strings.Join(sharedCredentialsFiles, ", ")+".",
+			)
+			return
+		}
 	}
 
 	// If any of the expected configuration are missing, return errors with
@@ -144,74 +388,196 @@ func (p *byteplusProvider) Configure(ctx context.Context, req provider.Configure
 			"Missing Byteplus API region",
 			"The provider cannot create the Byteplus API client as there is a "+
 				"missing or empty value for the Byteplus API region. Set the "+
-				"region value in the configuration or use the BYTEPLUS_REGION "+
-				"environment variable. If either is already set, ensure the value "+
-				"is not empty.",
+				"region value in the configuration, use the BYTEPLUS_REGION "+
+				"environment variable or set the region in the shared credentials "+
+				"profile. If any is already set, ensure the value is not empty.",
 		)
 	}
 
-	if accessKey == "" {
+	if accessKey == "" && credentialProcess == "" {
 		resp.Diagnostics.AddAttributeError(
 			path.Root("access_key"),
 			"Missing Byteplus API access key",
 			"The provider cannot create the Byteplus API client as there is a "+
 				"missing or empty value for the Byteplus API access key. Set the "+
-				"access key value in the configuration or use the BYTEPLUS_ACCESS_KEY "+
-				"environment variable. If either is already set, ensure the value "+
+				"access key value in the configuration, use the BYTEPLUS_ACCESS_KEY "+
+				"environment variable or set byteplus_access_key_id in the shared "+
+				"credentials profile. If any is already set, ensure the value "+
 				"is not empty.",
 		)
 	}
 
-	if secretKey == "" {
+	if secretKey == "" && credentialProcess == "" {
 		resp.Diagnostics.AddAttributeError(
 			path.Root("secret_key"),
 			"Missing Byteplus secret key",
 			"The provider cannot create the Byteplus API client as there is a "+
 				"missing or empty value for the Byteplus API Secret Key. Set the "+
-				"secret key value in the configuration or use the BYTEPLUS_SECRET_KEY "+
-				"environment variable. If either is already set, ensure the value "+
+				"secret key value in the configuration, use the BYTEPLUS_SECRET_KEY "+
+				"environment variable or set byteplus_secret_access_key in the shared "+
+				"credentials profile. If any is already set, ensure the value "+
 				"is not empty.",
 		)
 	}
 
+	if config.AssumeRole != nil {
+		resp.Diagnostics.Append(validateAssumeRoleConfig(config.AssumeRole, path.Root("assume_role"))...)
+	}
+
+	if config.DefaultTags.IsUnknown() || config.DefaultProjectName.IsUnknown() {
+		resp.Diagnostics.AddError(
+			"Unknown Byteplus default tags and project",
+			"The provider cannot create the Byteplus API client as there is an unknown configuration value for the"+
+				"Byteplus default tags and project. Set the values of default_tags and default_project_name "+
+				"statically in the configuration.",
+		)
+	}
+
+	defaultTags := make(map[string]string)
+	if !config.DefaultTags.IsNull() && !config.DefaultTags.IsUnknown() {
+		resp.Diagnostics.Append(config.DefaultTags.ElementsAs(ctx, &defaultTags, false)...)
+	}
+
+	if config.Endpoints == nil {
+		config.Endpoints = &endpointsConfig{}
+	}
+
+	if config.Endpoints.Cdn.IsUnknown() || config.Endpoints.Iam.IsUnknown() || config.Endpoints.Sts.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("endpoints"),
+			"Unknown Byteplus endpoints",
+			"The provider cannot create the Byteplus API client as there is an unknown configuration value for the"+
+				"Byteplus endpoints. Set the value statically in the configuration, or use the BYTEPLUS_CDN_ENDPOINT, "+
+				"BYTEPLUS_IAM_ENDPOINT and BYTEPLUS_STS_ENDPOINT environment variables.",
+		)
+	}
+
+	if config.HttpProxy.IsUnknown() || config.CaBundle.IsUnknown() ||
+		config.Insecure.IsUnknown() || config.RequestTimeout.IsUnknown() {
+		resp.Diagnostics.AddError(
+			"Unknown Byteplus HTTP settings",
+			"The provider cannot create the Byteplus API client as there is an unknown configuration value for the"+
+				"Byteplus HTTP settings. Set the values of http_proxy, ca_bundle, insecure and request_timeout "+
+				"statically in the configuration.",
+		)
+	}
+
+	if config.RequestTimeout.ValueInt64() < 0 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("request_timeout"),
+			"Invalid Byteplus request timeout",
+			"The request timeout must not be negative.",
+		)
+	}
+
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	clientCredentialsConfig := byteplusBaseClient.Credentials{
-		AccessKeyID:     accessKey,
-		SecretAccessKey: secretKey,
-		Region:          region,
+	caBundle := os.Getenv("BYTEPLUS_CA_BUNDLE")
+	if !config.CaBundle.IsNull() {
+		caBundle = config.CaBundle.ValueString()
 	}
 
-	// Byteplus CDN Client
-	cdnClientConfig := clientCredentialsConfig
-	cdnClient := byteplusCdnClient.NewInstance()
-	cdnClient.Client.SetCredential(cdnClientConfig)
+	httpClient, err := newHttpClient(
+		config.HttpProxy.ValueString(),
+		caBundle,
+		config.Insecure.ValueBool(),
+		time.Duration(config.RequestTimeout.ValueInt64())*time.Second,
+	)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unable to Create Byteplus HTTP Client",
+			"The provider cannot create the Byteplus API client as the HTTP "+
+				"settings are invalid.\n\n"+err.Error(),
+		)
+		return
+	}
 
-	//BytePlus IAM Client
-	clientCredentialsConfigNew := byteplus.NewConfig().
-		WithCredentials(credentials.NewStaticCredentials(accessKey, secretKey, "")).
-		WithRegion(region)
+	endpoints := serviceEndpoints{
+		cdn: os.Getenv("BYTEPLUS_CDN_ENDPOINT"),
+		iam: os.Getenv("BYTEPLUS_IAM_ENDPOINT"),
+		sts: os.Getenv("BYTEPLUS_STS_ENDPOINT"),
+	}
+	if !config.Endpoints.Cdn.IsNull() {
+		endpoints.cdn = config.Endpoints.Cdn.ValueString()
+	}
+	if !config.Endpoints.Iam.IsNull() {
+		endpoints.iam = config.Endpoints.Iam.ValueString()
+	}
+	if !config.Endpoints.Sts.IsNull() {
+		endpoints.sts = config.Endpoints.Sts.ValueString()
+	}
 
-	sess, err := session.NewSession(clientCredentialsConfigNew)
+	var providerCredentials *credentials.Credentials
+	if credentialProcess != "" {
+		providerCredentials = processcreds.NewCredentials(credentialProcess, func(p *processcreds.ProcessProvider) {
+			p.ExpiryWindow = processCredentialsExpiryWindow
+		})
+	} else {
+		providerCredentials = credentials.NewStaticCredentials(accessKey, secretKey, sessionToken)
+	}
+	var roleTrn string
+	if config.AssumeRole != nil {
+		roleTrn = config.AssumeRole.RoleTrn.ValueString()
+		providerCredentials = newAssumeRoleCredentials(providerCredentials, region, endpoints.sts, httpClient, config.AssumeRole)
+	}
 
+	// Byteplus clients of CDN, IAM and the other services are created by the
+	// factory on demand.
+	clients, err := newClientFactory(providerCredentials, roleTrn, region, endpoints, httpClient)
 	if err != nil {
 		resp.Diagnostics.AddError(
-			"Unable to Create AliCloud IAM API Client",
-			"An unexpected error occurred when creating the AliCloud IAM API client. "+
-				"If the error is not clear, please contact the provider developers.\n\n"+
+			"Unable to Create Byteplus IAM API Client",
+			"An unexpected error occurred when creating the Byteplus IAM API client.\n\n"+
 				"BytePlus IAM Client Error: "+err.Error(),
 		)
 		return
 	}
 
-	iamClient := byteplusIamClient.New(sess)
+	// The credentials are only retrieved when the first API is called if the
+	// validation is skipped, so neither the credential process is run nor the
+	// role is assumed at configure time.
+	var identity *callerIdentity
+	if !config.SkipCredsValidation.ValueBool() {
+		_, err = providerCredentials.Get()
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to Retrieve Byteplus Credentials",
+				"The provider cannot create the Byteplus API client as the credentials "+
+					"cannot be retrieved.\n\n"+err.Error(),
+			)
+			return
+		}
+
+		identity, err = clients.callerIdentity(ctx)
+		if err != nil {
+			summary := "Unable to Validate Byteplus Credentials"
+			if isCredentialsError(apiErrorCode(err)) {
+				summary = "Invalid Byteplus Credentials"
+			}
+
+			resp.Diagnostics.AddError(
+				summary,
+				"The provider failed to validate the credentials with STS GetCallerIdentity API. "+
+					"Ensure the access key and secret key are valid and not revoked, or set "+
+					"skip_credentials_validation to true to skip the validation.\n\n"+
+					"BytePlus STS Client Error: "+err.Error(),
+			)
+			return
+		}
+
+		tflog.Debug(ctx, "Validated Byteplus credentials", map[string]interface{}{
+			"account_id": identity.AccountId,
+		})
+	}
 
 	// Byteplus clients wrapper
 	byteplusClients := byteplusClients{
-		cdnClient: cdnClient,
-		iamClient: iamClient,
+		clients:            clients,
+		identity:           identity,
+		defaultTags:        defaultTags,
+		defaultProjectName: config.DefaultProjectName.ValueString(),
 	}
 
 	// Make the Byteplus client available during DataSource and Resource type
@@ -223,11 +589,21 @@ func (p *byteplusProvider) Configure(ctx context.Context, req provider.Configure
 func (p *byteplusProvider) DataSources(_ context.Context) []func() datasource.DataSource {
 	return []func() datasource.DataSource{
 		NewCdnDomainDataSource,
+		NewCdnDomainsDataSource,
+		NewCdnCertificateInfoDataSource,
+		NewCallerIdentityDataSource,
 	}
 }
 
 func (p *byteplusProvider) Resources(_ context.Context) []func() resource.Resource {
 	return []func() resource.Resource{
 		NewIamPolicyResource,
+		NewCdnDomainResource,
+		NewCdnCacheRefreshResource,
+		NewCdnPreloadResource,
+		NewCdnCertificateResource,
+		NewCdnDomainHttpsResource,
+		NewCdnCacheRulesResource,
+		NewCdnDomainOriginResource,
 	}
 }
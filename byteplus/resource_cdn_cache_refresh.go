@@ -0,0 +1,255 @@
+package byteplus
+
+import (
+	"context"
+	"strings"
+
+	byteplusCdnClient "github.com/byteplus-sdk/byteplus-sdk-golang/service/cdn"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ resource.Resource                   = &cdnCacheRefreshResource{}
+	_ resource.ResourceWithConfigure      = &cdnCacheRefreshResource{}
+	_ resource.ResourceWithValidateConfig = &cdnCacheRefreshResource{}
+)
+
+func NewCdnCacheRefreshResource() resource.Resource {
+	return &cdnCacheRefreshResource{}
+}
+
+type cdnCacheRefreshResource struct {
+	clients *clientFactory
+}
+
+type cdnCacheRefreshResourceModel struct {
+	ClientConfig      *resourceClientConfig `tfsdk:"client_config"`
+	Id                types.String          `tfsdk:"id"`
+	Type              types.String          `tfsdk:"type"`
+	Urls              types.List            `tfsdk:"urls"`
+	Triggers          types.Map             `tfsdk:"triggers"`
+	WaitForCompletion types.Bool            `tfsdk:"wait_for_completion"`
+	WaitTimeout       types.String          `tfsdk:"wait_timeout"`
+	TaskIds           types.List            `tfsdk:"task_ids"`
+	QuotaLimit        types.Int64           `tfsdk:"quota_limit"`
+	QuotaRemaining    types.Int64           `tfsdk:"quota_remaining"`
+}
+
+func (r *cdnCacheRefreshResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_cdn_cache_refresh"
+}
+
+func (r *cdnCacheRefreshResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Submits CDN refresh tasks to purge the caches of URLs or directories. The tasks are " +
+			"submitted again whenever `urls`, `type` or `triggers` changes, and nothing is done when the " +
+			"resource is destroyed. " +
+			"Creation fails without submitting anything if the daily quota is not enough. Once submitted, " +
+			"the tasks are recorded in state, and failing to submit the rest of the URLs, to describe the " +
+			"tasks or any URL failed to refresh fails the creation and taints the resource, so all the URLs " +
+			"are submitted again on the next apply. Without `wait_for_completion`, the tasks are described " +
+			"once after submitting, so the URLs still running are not checked.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The IDs of the refresh tasks separated by commas.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"type": schema.StringAttribute{
+				Description: "The type of the refresh, `file` to refresh the URLs, or `dir` to refresh " +
+					"all the files under the directories. Default to `file`.",
+				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString(cdnRefreshTypeFile),
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"urls": schema.ListAttribute{
+				Description: "The URLs of the files or directories to refresh, e.g. " +
+					"`https://www.example.com/index.html`. The URLs of directories must end with `/`. " +
+					"The URLs are submitted in batches within the limit of a single request, " +
+					"1000 for `file` and 50 for `dir`.",
+				Required:    true,
+				ElementType: types.StringType,
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.RequiresReplace(),
+				},
+			},
+			"triggers": schema.MapAttribute{
+				Description: "Arbitrary values that submit the refresh tasks again when changed, " +
+					"e.g. the version of a deployment.",
+				Optional:    true,
+				ElementType: types.StringType,
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplace(),
+				},
+			},
+			"wait_for_completion": schema.BoolAttribute{
+				Description: "Whether to wait until the refresh tasks complete. Default to `true`.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(true),
+			},
+			"wait_timeout": schema.StringAttribute{
+				Description: "The maximum time to wait for the refresh tasks to complete, e.g. `10m`. " +
+					"Default to `20m`.",
+				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString("20m"),
+			},
+			"task_ids": schema.ListAttribute{
+				Description: "The IDs of the refresh tasks, one for each batch of URLs.",
+				Computed:    true,
+				ElementType: types.StringType,
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"quota_limit": schema.Int64Attribute{
+				Description: "The daily quota of the URLs of the refresh type.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"quota_remaining": schema.Int64Attribute{
+				Description: "The remaining daily quota of the URLs of the refresh type after the " +
+					"tasks are submitted.",
+				Computed: true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"client_config": resourceClientConfigBlock("CDN domains", "refresh CDN caches"),
+		},
+	}
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *cdnCacheRefreshResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	r.clients = req.ProviderData.(byteplusClients).clients
+}
+
+// ValidateConfig validates the refresh type, the URLs and the wait timeout.
+func (r *cdnCacheRefreshResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config *cdnCacheRefreshResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	validateStringInSlice(&resp.Diagnostics, path.Root("type"), config.Type, cdnRefreshTypes)
+	validateContentUrls(&resp.Diagnostics, path.Root("urls"), config.Urls, config.Type.ValueString() == cdnRefreshTypeDir)
+	parseWaitTimeout(&resp.Diagnostics, path.Root("wait_timeout"), config.WaitTimeout, cdnContentTaskTimeout)
+}
+
+// Create submits the refresh tasks and waits until they complete.
+func (r *cdnCacheRefreshResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan *cdnCacheRefreshResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(plan.ClientConfig.setWriteOnlyKeys(ctx, req.Config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, clientDiags := r.clients.cdnClient(plan.ClientConfig.toClientConfig())
+	resp.Diagnostics.Append(clientDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var urls []string
+	resp.Diagnostics.Append(plan.Urls.ElementsAs(ctx, &urls, false)...)
+	waitTimeout := parseWaitTimeout(&resp.Diagnostics, path.Root("wait_timeout"), plan.WaitTimeout, cdnContentTaskTimeout)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	urls = uniqueStrings(urls)
+
+	refreshType := plan.Type.ValueString()
+	taskType, batchSize := cdnContentTaskTypeRefreshFile, cdnRefreshFileUrlsPerRequest
+	if refreshType == cdnRefreshTypeDir {
+		taskType, batchSize = cdnContentTaskTypeRefreshDir, cdnRefreshDirUrlsPerRequest
+	}
+
+	result, diags := runCdnContentTasks(ctx, client, cdnContentTaskRun{
+		operation: "Refresh",
+		action:    "SubmitRefreshTask",
+		taskType:  taskType,
+		batchSize: batchSize,
+		submit: func(batch string) (string, error) {
+			response, err := client.SubmitRefreshTask(&byteplusCdnClient.SubmitRefreshTaskRequest{
+				Type: byteplusCdnClient.GetStrPtr(refreshType),
+				Urls: batch,
+			})
+			if err != nil {
+				return "", err
+			}
+			return response.Result.TaskID, nil
+		},
+	}, urls, plan.WaitForCompletion.ValueBool(), waitTimeout)
+	resp.Diagnostics.Append(diags...)
+	if len(result.taskIds) == 0 {
+		return
+	}
+
+	// Record the submitted tasks even with errors, the resource is then
+	// tainted and the URLs are submitted again on the next apply.
+	state := *plan
+	state.Id = types.StringValue(strings.Join(result.taskIds, ","))
+	taskIdsValue, diags := types.ListValueFrom(ctx, types.StringType, result.taskIds)
+	resp.Diagnostics.Append(diags...)
+	state.TaskIds = taskIdsValue
+	state.QuotaLimit = types.Int64Null()
+	state.QuotaRemaining = types.Int64Null()
+	if result.quota != nil {
+		state.QuotaLimit = types.Int64Value(result.quota.limit)
+		state.QuotaRemaining = types.Int64Value(result.quota.remain)
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Read does nothing, the refresh tasks are one-off and cannot drift.
+func (r *cdnCacheRefreshResource) Read(_ context.Context, _ resource.ReadRequest, _ *resource.ReadResponse) {
+}
+
+// Update only changes the wait options, the other changes submit the refresh
+// tasks again with replacement.
+func (r *cdnCacheRefreshResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan *cdnCacheRefreshResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+// Delete only removes the refresh tasks from state, the purged caches cannot
+// be restored.
+func (r *cdnCacheRefreshResource) Delete(_ context.Context, _ resource.DeleteRequest, _ *resource.DeleteResponse) {
+}
@@ -0,0 +1,658 @@
+package byteplus
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/byteplus-sdk/byteplus-go-sdk-v2/byteplus"
+
+	byteplusCdnClient "github.com/byteplus-sdk/byteplus-sdk-golang/service/cdn"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ resource.Resource                   = &cdnCacheRulesResource{}
+	_ resource.ResourceWithConfigure      = &cdnCacheRulesResource{}
+	_ resource.ResourceWithImportState    = &cdnCacheRulesResource{}
+	_ resource.ResourceWithValidateConfig = &cdnCacheRulesResource{}
+)
+
+const (
+	cdnCacheConditionPath      = "path"
+	cdnCacheConditionDirectory = "directory"
+	cdnCacheConditionSuffix    = "suffix"
+	cdnCacheConditionRegex     = "regex"
+
+	// The condition of the cache rules in CDN API, the values of a condition
+	// are joined by semicolons.
+	cdnConditionTypeUrl         = "url"
+	cdnConditionObjectPath      = "path"
+	cdnConditionObjectDirectory = "directory"
+	cdnConditionObjectFileType  = "filetype"
+	cdnConditionOperatorMatch   = "match"
+	cdnConditionOperatorRegex   = "regex"
+	cdnConditionConnectiveOr    = "OR"
+	cdnConditionValueSeparator  = ";"
+
+	cdnCacheActionCache                = "cache"
+	cdnCachePolicyHonourOrigin         = "default"
+	cdnCachePolicyForceCache           = "force_cache"
+	cdnCacheKeyActionExclude           = "exclude"
+	cdnCacheKeyObjectQueryString       = "queryString"
+	cdnCacheKeySubobjectAll            = "*"
+	cdnCacheRuleMaxTtl           int64 = 315360000
+)
+
+var cdnCacheConditionTypes = []string{
+	cdnCacheConditionPath,
+	cdnCacheConditionDirectory,
+	cdnCacheConditionSuffix,
+	cdnCacheConditionRegex,
+}
+
+func NewCdnCacheRulesResource() resource.Resource {
+	return &cdnCacheRulesResource{}
+}
+
+type cdnCacheRulesResource struct {
+	clients *clientFactory
+}
+
+type cdnCacheRulesResourceModel struct {
+	ClientConfig *resourceClientConfig `tfsdk:"client_config"`
+	Domain       types.String          `tfsdk:"domain_name"`
+	Rules        []*cdnCacheRuleModel  `tfsdk:"rule"`
+}
+
+type cdnCacheRuleModel struct {
+	ConditionType       types.String `tfsdk:"condition_type"`
+	Values              types.List   `tfsdk:"values"`
+	Ttl                 types.Int64  `tfsdk:"ttl"`
+	HonourOriginHeaders types.Bool   `tfsdk:"honour_origin_headers"`
+	IgnoreQueryString   types.Bool   `tfsdk:"ignore_query_string"`
+}
+
+func (r *cdnCacheRulesResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_cdn_cache_rules"
+}
+
+func (r *cdnCacheRulesResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages the ordered cache rules of a CDN domain. The resource owns all the cache " +
+			"rules of the domain, rules added outside of Terraform are shown as drift and removed on " +
+			"the next apply. Rules with a condition the resource cannot represent, e.g. added in the " +
+			"console, are reported as warnings and removed on the next apply that changes the rules. " +
+			"Only the cache key rules that ignore the whole query string for `ignore_query_string` are " +
+			"managed, the other cache key rules of the domain are kept. Destroying the resource removes " +
+			"all the cache rules and the managed cache key rules of the domain, the default rules added " +
+			"when the domain was created are not restored.",
+		Attributes: map[string]schema.Attribute{
+			"domain_name": schema.StringAttribute{
+				Description: "Domain name of CDN domain.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"rule": schema.ListNestedBlock{
+				Description: "The cache rules in the same order as the console, at least one rule is " +
+					"required. Reordering the rules is a change of the resource.",
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"condition_type": schema.StringAttribute{
+							Description: "How the request path is matched, valid values are `path` for " +
+								"full paths, `directory` for directories, `suffix` for file extensions " +
+								"and `regex` for a regular expression.",
+							Required: true,
+						},
+						"values": schema.ListAttribute{
+							Description: "The values to match, e.g. `/index.html` for `path`, `/static/` " +
+								"for `directory`, `jpg` for `suffix` and `^/api/v[0-9]+/` for `regex`. " +
+								"A request matches if any of the values matches, only one value is " +
+								"allowed for `regex`. Use `directory` with `/` to match all requests.",
+							Required:    true,
+							ElementType: types.StringType,
+						},
+						"ttl": schema.Int64Attribute{
+							Description: "How long the content is cached in seconds, between 0 and " +
+								"315360000. 0 means the content is not cached.",
+							Required: true,
+						},
+						"honour_origin_headers": schema.BoolAttribute{
+							Description: "Whether the cache headers of the origin, e.g. Cache-Control, " +
+								"take precedence over `ttl`. Default to `false`.",
+							Optional: true,
+							Computed: true,
+							Default:  booldefault.StaticBool(false),
+						},
+						"ignore_query_string": schema.BoolAttribute{
+							Description: "Whether the query string is ignored in the cache key, so " +
+								"requests with different query strings share the same cache. " +
+								"Default to `false`.",
+							Optional: true,
+							Computed: true,
+							Default:  booldefault.StaticBool(false),
+						},
+					},
+				},
+			},
+			"client_config": resourceClientConfigBlock("CDN domain", "manage CDN domains"),
+		},
+	}
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *cdnCacheRulesResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	r.clients = req.ProviderData.(byteplusClients).clients
+}
+
+// ValidateConfig validates the conditions and TTL of the cache rules.
+func (r *cdnCacheRulesResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config *cdnCacheRulesResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if len(config.Rules) == 0 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("rule"),
+			"Missing Cache Rule",
+			"At least one cache rule is required.",
+		)
+	}
+
+	for i, rule := range config.Rules {
+		rulePath := path.Root("rule").AtListIndex(i)
+		validateStringInSlice(&resp.Diagnostics, rulePath.AtName("condition_type"), rule.ConditionType, cdnCacheConditionTypes)
+
+		if !rule.Ttl.IsNull() && !rule.Ttl.IsUnknown() {
+			if ttl := rule.Ttl.ValueInt64(); ttl < 0 || ttl > cdnCacheRuleMaxTtl {
+				resp.Diagnostics.AddAttributeError(
+					rulePath.AtName("ttl"),
+					"Invalid Cache TTL",
+					fmt.Sprintf("The TTL of the cache rule must be between 0 and %d, got %d.", cdnCacheRuleMaxTtl, ttl),
+				)
+			}
+		}
+
+		if rule.ConditionType.IsUnknown() || rule.Values.IsNull() || rule.Values.IsUnknown() {
+			continue
+		}
+		validateCdnCacheRuleValues(&resp.Diagnostics, rulePath.AtName("values"), rule.ConditionType.ValueString(), rule.Values)
+	}
+}
+
+// Create replaces the cache rules of the CDN domain with the planned rules.
+func (r *cdnCacheRulesResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan *cdnCacheRulesResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(plan.ClientConfig.setWriteOnlyKeys(ctx, req.Config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, clientDiags := r.clients.cdnClient(plan.ClientConfig.toClientConfig())
+	resp.Diagnostics.Append(clientDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.updateRules(ctx, client, plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state := *plan
+	_, diags := r.readRules(ctx, client, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Read refreshes the cache rules of the CDN domain to detect drift, including
+// rules added, removed or reordered outside of Terraform. The resource is
+// removed from state if the domain no longer exists.
+func (r *cdnCacheRulesResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state *cdnCacheRulesResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, clientDiags := r.clients.cdnClient(state.ClientConfig.toClientConfig())
+	resp.Diagnostics.Append(clientDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	found, diags := r.readRules(ctx, client, state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if !found {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Update replaces the cache rules of the CDN domain with the planned rules.
+func (r *cdnCacheRulesResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan *cdnCacheRulesResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(plan.ClientConfig.setWriteOnlyKeys(ctx, req.Config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, clientDiags := r.clients.cdnClient(plan.ClientConfig.toClientConfig())
+	resp.Diagnostics.Append(clientDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.updateRules(ctx, client, plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state := *plan
+	_, diags := r.readRules(ctx, client, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Delete removes all the cache rules and the cache key rules ignoring the
+// query string of the CDN domain, the other cache key rules are kept. The
+// default rules of the domain are not restored, as the API has no way to reset
+// them.
+func (r *cdnCacheRulesResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state *cdnCacheRulesResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, clientDiags := r.clients.cdnClient(state.ClientConfig.toClientConfig())
+	resp.Diagnostics.Append(clientDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	domainName := state.Domain.ValueString()
+	cdnDomain, err := describeCdnDomain(ctx, client, domainName)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"[API ERROR] Failed to Describe CDN Domain.",
+			err.Error(),
+		)
+		return
+	}
+	if cdnDomain == nil {
+		return
+	}
+
+	domainConfig, err := describeCdnDomainConfig(ctx, client, domainName)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"[API ERROR] Failed to Describe CDN Domain Config.",
+			err.Error(),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(updateCdnDomainConfig(ctx, client, &byteplusCdnClient.UpdateCdnConfigRequest{
+		Domain:   byteplusCdnClient.GetStrPtr(domainName),
+		Cache:    []byteplusCdnClient.CacheControlRule{},
+		CacheKey: mergeCdnCacheKeyRules(nil, domainConfig.CacheKey),
+	})...)
+}
+
+func (r *cdnCacheRulesResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("domain_name"), req, resp)
+}
+
+// updateRules replaces the cache rules of the CDN domain with the rules of the
+// plan. As the API replaces all the cache key rules at once, the cache key
+// rules not managed by the resource are read and sent again after the rules
+// of the plan.
+func (r *cdnCacheRulesResource) updateRules(ctx context.Context, client *byteplusCdnClient.CDN, plan *cdnCacheRulesResourceModel) (diags diag.Diagnostics) {
+	cacheRules, cacheKeyRules, diags := expandCdnCacheRules(ctx, plan.Rules)
+	if diags.HasError() {
+		return
+	}
+
+	domainName := plan.Domain.ValueString()
+	domainConfig, err := describeCdnDomainConfig(ctx, client, domainName)
+	if err != nil {
+		diags.AddError(
+			"[API ERROR] Failed to Describe CDN Domain Config.",
+			err.Error(),
+		)
+		return
+	}
+
+	return updateCdnDomainConfig(ctx, client, &byteplusCdnClient.UpdateCdnConfigRequest{
+		Domain:   byteplusCdnClient.GetStrPtr(domainName),
+		Cache:    cacheRules,
+		CacheKey: mergeCdnCacheKeyRules(cacheKeyRules, domainConfig.CacheKey),
+	})
+}
+
+// readRules reads the cache rules of the CDN domain into the state in the
+// order returned by the API.
+//
+// Parameters:
+//   - ctx: Context.
+//   - client: The CDN client.
+//   - state: The state to read into.
+//
+// Returns:
+//   - found: Whether the domain exists.
+//   - diags: Diagnostics of describing the domain, or warnings of the cache
+//     rules that cannot be represented by the resource.
+func (r *cdnCacheRulesResource) readRules(ctx context.Context, client *byteplusCdnClient.CDN, state *cdnCacheRulesResourceModel) (found bool, diags diag.Diagnostics) {
+	domainName := state.Domain.ValueString()
+	cdnDomain, err := describeCdnDomain(ctx, client, domainName)
+	if err != nil {
+		diags.AddError(
+			"[API ERROR] Failed to Describe CDN Domain.",
+			err.Error(),
+		)
+		return
+	}
+	if cdnDomain == nil {
+		return false, diags
+	}
+
+	domainConfig, err := describeCdnDomainConfig(ctx, client, domainName)
+	if err != nil {
+		diags.AddError(
+			"[API ERROR] Failed to Describe CDN Domain Config.",
+			err.Error(),
+		)
+		return
+	}
+
+	state.Rules, diags = flattenCdnCacheRules(ctx, domainConfig.Cache, domainConfig.CacheKey)
+	return !diags.HasError(), diags
+}
+
+// validateCdnCacheRuleValues validates the values match the condition type of
+// the cache rule.
+func validateCdnCacheRuleValues(diags *diag.Diagnostics, attributePath path.Path, conditionType string, values types.List) {
+	if len(values.Elements()) == 0 {
+		diags.AddAttributeError(
+			attributePath,
+			"Missing Cache Rule Values",
+			"At least one value is required for the cache rule.",
+		)
+		return
+	}
+	if conditionType == cdnCacheConditionRegex && len(values.Elements()) > 1 {
+		diags.AddAttributeError(
+			attributePath,
+			"Invalid Cache Rule Values",
+			fmt.Sprintf("Only one regular expression is allowed for the cache rule, got %d.", len(values.Elements())),
+		)
+	}
+
+	for i, element := range values.Elements() {
+		value, ok := element.(types.String)
+		if !ok || value.IsNull() || value.IsUnknown() {
+			continue
+		}
+
+		valuePath := attributePath.AtListIndex(i)
+		text := value.ValueString()
+		switch {
+		case strings.Contains(text, cdnConditionValueSeparator):
+			diags.AddAttributeError(
+				valuePath,
+				"Invalid Cache Rule Value",
+				fmt.Sprintf("The value %q must not contain %q, use separate values instead.", text, cdnConditionValueSeparator),
+			)
+		case (conditionType == cdnCacheConditionPath || conditionType == cdnCacheConditionDirectory) && !strings.HasPrefix(text, "/"):
+			diags.AddAttributeError(
+				valuePath,
+				"Invalid Cache Rule Value",
+				fmt.Sprintf("The %s %q must start with /.", conditionType, text),
+			)
+		case conditionType == cdnCacheConditionSuffix && (text == "" || strings.ContainsAny(text, "./")):
+			diags.AddAttributeError(
+				valuePath,
+				"Invalid Cache Rule Value",
+				fmt.Sprintf("The suffix %q must be a file extension without the leading dot, e.g. jpg.", text),
+			)
+		case conditionType == cdnCacheConditionRegex:
+			if _, err := regexp.Compile(text); err != nil {
+				diags.AddAttributeError(
+					valuePath,
+					"Invalid Cache Rule Value",
+					fmt.Sprintf("The regular expression %q is invalid: %s", text, err.Error()),
+				)
+			}
+		}
+	}
+}
+
+// expandCdnCacheRules converts the cache rules to the cache rules and the
+// cache key rules of CDN API. A cache key rule with the same condition is
+// added for each cache rule that ignores the query string.
+func expandCdnCacheRules(ctx context.Context, rules []*cdnCacheRuleModel) (cacheRules []byteplusCdnClient.CacheControlRule, cacheKeyRules []byteplusCdnClient.CacheKeyRule, diags diag.Diagnostics) {
+	cacheRules = make([]byteplusCdnClient.CacheControlRule, 0, len(rules))
+	cacheKeyRules = make([]byteplusCdnClient.CacheKeyRule, 0)
+	for _, rule := range rules {
+		var values []string
+		diags.Append(rule.Values.ElementsAs(ctx, &values, false)...)
+		if diags.HasError() {
+			return
+		}
+
+		defaultPolicy := cdnCachePolicyForceCache
+		if rule.HonourOriginHeaders.ValueBool() {
+			defaultPolicy = cdnCachePolicyHonourOrigin
+		}
+
+		cacheRules = append(cacheRules, byteplusCdnClient.CacheControlRule{
+			Condition: expandCdnCacheCondition(rule.ConditionType.ValueString(), values),
+			CacheAction: &byteplusCdnClient.CacheAction{
+				Action:        byteplusCdnClient.GetStrPtr(cdnCacheActionCache),
+				DefaultPolicy: byteplusCdnClient.GetStrPtr(defaultPolicy),
+				Ttl:           byteplusCdnClient.GetInt64Ptr(rule.Ttl.ValueInt64()),
+			},
+		})
+
+		if rule.IgnoreQueryString.ValueBool() {
+			cacheKeyRules = append(cacheKeyRules, byteplusCdnClient.CacheKeyRule{
+				Condition: expandCdnCacheCondition(rule.ConditionType.ValueString(), values),
+				CacheKeyAction: &byteplusCdnClient.CacheKeyAction{
+					CacheKeyComponents: []byteplusCdnClient.CacheKeyComponent{
+						{
+							Action:    byteplusCdnClient.GetStrPtr(cdnCacheKeyActionExclude),
+							Object:    byteplusCdnClient.GetStrPtr(cdnCacheKeyObjectQueryString),
+							Subobject: byteplusCdnClient.GetStrPtr(cdnCacheKeySubobjectAll),
+						},
+					},
+				},
+			})
+		}
+	}
+
+	return
+}
+
+// expandCdnCacheCondition converts the condition type and values of a cache
+// rule to the condition of CDN API.
+func expandCdnCacheCondition(conditionType string, values []string) *byteplusCdnClient.Condition {
+	object, operator := cdnConditionObjectPath, cdnConditionOperatorMatch
+	switch conditionType {
+	case cdnCacheConditionDirectory:
+		object = cdnConditionObjectDirectory
+	case cdnCacheConditionSuffix:
+		object = cdnConditionObjectFileType
+	case cdnCacheConditionRegex:
+		operator = cdnConditionOperatorRegex
+	}
+
+	return &byteplusCdnClient.Condition{
+		Connective: byteplusCdnClient.GetStrPtr(cdnConditionConnectiveOr),
+		ConditionRule: []byteplusCdnClient.ConditionRule{
+			{
+				Type:     byteplusCdnClient.GetStrPtr(cdnConditionTypeUrl),
+				Object:   byteplusCdnClient.GetStrPtr(object),
+				Operator: byteplusCdnClient.GetStrPtr(operator),
+				Value:    byteplusCdnClient.GetStrPtr(strings.Join(values, cdnConditionValueSeparator)),
+			},
+		},
+	}
+}
+
+// flattenCdnCacheCondition converts the condition of CDN API to the condition
+// type and values of a cache rule.
+//
+// Returns:
+//   - conditionType: The condition type of the cache rule.
+//   - values: The values of the condition.
+//   - ok: Whether the condition can be represented by a cache rule.
+func flattenCdnCacheCondition(condition *byteplusCdnClient.Condition) (conditionType string, values []string, ok bool) {
+	if condition == nil || len(condition.ConditionRule) != 1 {
+		return "", nil, false
+	}
+
+	conditionRule := condition.ConditionRule[0]
+	if stringValue(conditionRule.Type) != cdnConditionTypeUrl {
+		return "", nil, false
+	}
+
+	switch object, operator := stringValue(conditionRule.Object), stringValue(conditionRule.Operator); {
+	case object == cdnConditionObjectPath && operator == cdnConditionOperatorMatch:
+		conditionType = cdnCacheConditionPath
+	case object == cdnConditionObjectDirectory && operator == cdnConditionOperatorMatch:
+		conditionType = cdnCacheConditionDirectory
+	case object == cdnConditionObjectFileType && operator == cdnConditionOperatorMatch:
+		conditionType = cdnCacheConditionSuffix
+	case object == cdnConditionObjectPath && operator == cdnConditionOperatorRegex:
+		conditionType = cdnCacheConditionRegex
+	default:
+		return "", nil, false
+	}
+
+	return conditionType, strings.Split(stringValue(conditionRule.Value), cdnConditionValueSeparator), true
+}
+
+// flattenCdnCacheRules converts the cache rules and the cache key rules of CDN
+// API to the cache rules, a rule ignores the query string if a managed cache
+// key rule with the same condition excludes the query string. The rules with a
+// condition that cannot be represented are dropped with a warning, so they
+// never fail the refresh and are removed when the rules are updated.
+func flattenCdnCacheRules(ctx context.Context, cacheRules []byteplusCdnClient.CacheControlRule, cacheKeyRules []byteplusCdnClient.CacheKeyRule) (rules []*cdnCacheRuleModel, diags diag.Diagnostics) {
+	ignoreQueryStringConditions := make(map[string]bool)
+	for _, cacheKeyRule := range cacheKeyRules {
+		if !isManagedCdnCacheKeyRule(cacheKeyRule) {
+			continue
+		}
+
+		conditionType, values, _ := flattenCdnCacheCondition(cacheKeyRule.Condition)
+		ignoreQueryStringConditions[conditionType+"\n"+strings.Join(values, cdnConditionValueSeparator)] = true
+	}
+
+	for i, cacheRule := range cacheRules {
+		conditionType, values, ok := flattenCdnCacheCondition(cacheRule.Condition)
+		if !ok {
+			diags.AddWarning(
+				"Unsupported Cache Rule",
+				fmt.Sprintf("The cache rule %d of the domain has a condition that is not supported by the "+
+					"resource, it may be added in the console. The rule is not recorded in state and is "+
+					"removed on the next apply that changes the cache rules.", i+1),
+			)
+			continue
+		}
+
+		valuesList, listDiags := types.ListValueFrom(ctx, types.StringType, values)
+		diags.Append(listDiags...)
+		if diags.HasError() {
+			return
+		}
+
+		rule := &cdnCacheRuleModel{
+			ConditionType:       types.StringValue(conditionType),
+			Values:              valuesList,
+			Ttl:                 types.Int64Value(0),
+			HonourOriginHeaders: types.BoolValue(false),
+			IgnoreQueryString:   types.BoolValue(ignoreQueryStringConditions[conditionType+"\n"+strings.Join(values, cdnConditionValueSeparator)]),
+		}
+		if cacheRule.CacheAction != nil {
+			rule.Ttl = types.Int64Value(byteplus.Int64Value(cacheRule.CacheAction.Ttl))
+			rule.HonourOriginHeaders = types.BoolValue(stringValue(cacheRule.CacheAction.DefaultPolicy) == cdnCachePolicyHonourOrigin)
+		}
+		rules = append(rules, rule)
+	}
+
+	return
+}
+
+// isManagedCdnCacheKeyRule returns whether the cache key rule is managed by
+// the resource, i.e. it only excludes the whole query string on a condition
+// of a cache rule, as added by expandCdnCacheRules for ignore_query_string.
+func isManagedCdnCacheKeyRule(cacheKeyRule byteplusCdnClient.CacheKeyRule) bool {
+	if _, _, ok := flattenCdnCacheCondition(cacheKeyRule.Condition); !ok {
+		return false
+	}
+	if cacheKeyRule.CacheKeyAction == nil || len(cacheKeyRule.CacheKeyAction.CacheKeyComponents) != 1 {
+		return false
+	}
+
+	component := cacheKeyRule.CacheKeyAction.CacheKeyComponents[0]
+	return stringValue(component.Action) == cdnCacheKeyActionExclude &&
+		stringValue(component.Object) == cdnCacheKeyObjectQueryString &&
+		stringValue(component.Subobject) == cdnCacheKeySubobjectAll
+}
+
+// mergeCdnCacheKeyRules returns the cache key rules of the resource followed
+// by the remote cache key rules not managed by the resource, e.g. added in the
+// console, so updating the cache rules never removes them.
+func mergeCdnCacheKeyRules(cacheKeyRules, remoteCacheKeyRules []byteplusCdnClient.CacheKeyRule) []byteplusCdnClient.CacheKeyRule {
+	merged := make([]byteplusCdnClient.CacheKeyRule, 0, len(cacheKeyRules)+len(remoteCacheKeyRules))
+	merged = append(merged, cacheKeyRules...)
+	for _, remoteCacheKeyRule := range remoteCacheKeyRules {
+		if !isManagedCdnCacheKeyRule(remoteCacheKeyRule) {
+			merged = append(merged, remoteCacheKeyRule)
+		}
+	}
+
+	return merged
+}
@@ -0,0 +1,177 @@
+package byteplus
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	byteplusCdnClient "github.com/byteplus-sdk/byteplus-sdk-golang/service/cdn"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestFlattenCdnCacheRules(t *testing.T) {
+	ctx := context.Background()
+
+	newRule := func(conditionType string, values []string, ttl int64, honourOriginHeaders, ignoreQueryString bool) *cdnCacheRuleModel {
+		valuesList, diags := types.ListValueFrom(ctx, types.StringType, values)
+		if diags.HasError() {
+			t.Fatalf("unexpected diagnostics: %v", diags)
+		}
+
+		return &cdnCacheRuleModel{
+			ConditionType:       types.StringValue(conditionType),
+			Values:              valuesList,
+			Ttl:                 types.Int64Value(ttl),
+			HonourOriginHeaders: types.BoolValue(honourOriginHeaders),
+			IgnoreQueryString:   types.BoolValue(ignoreQueryString),
+		}
+	}
+
+	rules := []*cdnCacheRuleModel{
+		newRule(cdnCacheConditionDirectory, []string{"/"}, 3600, true, false),
+		newRule(cdnCacheConditionSuffix, []string{"jpg", "png"}, 86400, false, true),
+		newRule(cdnCacheConditionRegex, []string{"^/api/v[0-9]+/"}, 0, false, false),
+	}
+	cacheRules, cacheKeyRules, diags := expandCdnCacheRules(ctx, rules)
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	// A rule added in the console with a condition on the request header.
+	unsupported := byteplusCdnClient.CacheControlRule{
+		Condition: &byteplusCdnClient.Condition{
+			ConditionRule: []byteplusCdnClient.ConditionRule{
+				{
+					Type:     byteplusCdnClient.GetStrPtr("http"),
+					Object:   byteplusCdnClient.GetStrPtr("header"),
+					Operator: byteplusCdnClient.GetStrPtr(cdnConditionOperatorMatch),
+					Value:    byteplusCdnClient.GetStrPtr("x-debug"),
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		name         string
+		cacheRules   []byteplusCdnClient.CacheControlRule
+		want         []*cdnCacheRuleModel
+		wantWarnings int
+	}{
+		{
+			name:       "round trip",
+			cacheRules: cacheRules,
+			want:       rules,
+		},
+		{
+			name:         "unsupported rule dropped",
+			cacheRules:   append([]byteplusCdnClient.CacheControlRule{unsupported}, cacheRules...),
+			want:         rules,
+			wantWarnings: 1,
+		},
+		{
+			name:         "only unsupported rules",
+			cacheRules:   []byteplusCdnClient.CacheControlRule{unsupported, {}},
+			want:         nil,
+			wantWarnings: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, diags := flattenCdnCacheRules(ctx, tt.cacheRules, cacheKeyRules)
+			if diags.HasError() {
+				t.Fatalf("unexpected errors: %v", diags)
+			}
+			if warnings := diags.WarningsCount(); warnings != tt.wantWarnings {
+				t.Errorf("warnings = %d, want %d", warnings, tt.wantWarnings)
+			}
+
+			if len(got) != len(tt.want) {
+				t.Fatalf("rules = %d, want %d", len(got), len(tt.want))
+			}
+			for i := range got {
+				if !(got[i].ConditionType.Equal(tt.want[i].ConditionType) &&
+					got[i].Values.Equal(tt.want[i].Values) &&
+					got[i].Ttl.Equal(tt.want[i].Ttl) &&
+					got[i].HonourOriginHeaders.Equal(tt.want[i].HonourOriginHeaders) &&
+					got[i].IgnoreQueryString.Equal(tt.want[i].IgnoreQueryString)) {
+					t.Errorf("rule %d = %+v, want %+v", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestMergeCdnCacheKeyRules(t *testing.T) {
+	ctx := context.Background()
+
+	values, diags := types.ListValueFrom(ctx, types.StringType, []string{"/static/"})
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	_, managed, diags := expandCdnCacheRules(ctx, []*cdnCacheRuleModel{
+		{
+			ConditionType:       types.StringValue(cdnCacheConditionDirectory),
+			Values:              values,
+			Ttl:                 types.Int64Value(3600),
+			HonourOriginHeaders: types.BoolValue(false),
+			IgnoreQueryString:   types.BoolValue(true),
+		},
+	})
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	// A cache key rule added in the console that only excludes a parameter.
+	unmanaged := byteplusCdnClient.CacheKeyRule{
+		Condition: expandCdnCacheCondition(cdnCacheConditionSuffix, []string{"jpg"}),
+		CacheKeyAction: &byteplusCdnClient.CacheKeyAction{
+			CacheKeyComponents: []byteplusCdnClient.CacheKeyComponent{
+				{
+					Action:    byteplusCdnClient.GetStrPtr(cdnCacheKeyActionExclude),
+					Object:    byteplusCdnClient.GetStrPtr(cdnCacheKeyObjectQueryString),
+					Subobject: byteplusCdnClient.GetStrPtr("utm_source"),
+				},
+			},
+		},
+	}
+	// A managed cache key rule of a rule removed from the plan.
+	stale := byteplusCdnClient.CacheKeyRule{
+		Condition:      expandCdnCacheCondition(cdnCacheConditionPath, []string{"/index.html"}),
+		CacheKeyAction: managed[0].CacheKeyAction,
+	}
+
+	tests := []struct {
+		name   string
+		rules  []byteplusCdnClient.CacheKeyRule
+		remote []byteplusCdnClient.CacheKeyRule
+		want   []byteplusCdnClient.CacheKeyRule
+	}{
+		{
+			name:   "unmanaged rule kept after the managed rules",
+			rules:  managed,
+			remote: []byteplusCdnClient.CacheKeyRule{unmanaged, managed[0]},
+			want:   []byteplusCdnClient.CacheKeyRule{managed[0], unmanaged},
+		},
+		{
+			name:   "stale managed rule replaced",
+			rules:  managed,
+			remote: []byteplusCdnClient.CacheKeyRule{stale},
+			want:   managed,
+		},
+		{
+			name:   "delete keeps the unmanaged rules",
+			remote: []byteplusCdnClient.CacheKeyRule{stale, unmanaged},
+			want:   []byteplusCdnClient.CacheKeyRule{unmanaged},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := mergeCdnCacheKeyRules(tt.rules, tt.remote)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("cache key rules = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
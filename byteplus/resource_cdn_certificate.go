@@ -0,0 +1,393 @@
+package byteplus
+
+import (
+	"context"
+	"time"
+
+	byteplusCdnClient "github.com/byteplus-sdk/byteplus-sdk-golang/service/cdn"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ resource.Resource                   = &cdnCertificateResource{}
+	_ resource.ResourceWithConfigure      = &cdnCertificateResource{}
+	_ resource.ResourceWithValidateConfig = &cdnCertificateResource{}
+)
+
+func NewCdnCertificateResource() resource.Resource {
+	return &cdnCertificateResource{}
+}
+
+type cdnCertificateResource struct {
+	clients *clientFactory
+}
+
+type cdnCertificateResourceModel struct {
+	ClientConfig            *resourceClientConfig `tfsdk:"client_config"`
+	Id                      types.String          `tfsdk:"id"`
+	Certificate             types.String          `tfsdk:"certificate"`
+	PrivateKey              types.String          `tfsdk:"private_key"`
+	Description             types.String          `tfsdk:"description"`
+	ValidateChain           types.Bool            `tfsdk:"validate_chain"`
+	CertName                types.String          `tfsdk:"cert_name"`
+	Status                  types.String          `tfsdk:"status"`
+	CommonName              types.String          `tfsdk:"common_name"`
+	SubjectAlternativeNames types.List            `tfsdk:"subject_alternative_names"`
+	Fingerprint             types.String          `tfsdk:"fingerprint"`
+	EffectiveTime           types.String          `tfsdk:"effective_time"`
+	ExpireTime              types.String          `tfsdk:"expire_time"`
+}
+
+func (r *cdnCertificateResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_cdn_certificate"
+}
+
+func (r *cdnCertificateResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Uploads a certificate to the certificate hosting of CDN. The certificate and the " +
+			"private key are validated locally before they are uploaded, and any change of them uploads " +
+			"a new certificate.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The ID of the certificate.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"certificate": schema.StringAttribute{
+				Description: "The certificate in PEM format, the leaf certificate first followed by the " +
+					"intermediate certificates.",
+				Required: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"private_key": schema.StringAttribute{
+				Description: "The private key of the certificate in PEM format. The private key is " +
+					"write-only and never recorded in plan or state, it requires Terraform 1.11 or " +
+					"later. The private key must match the certificate, so a new private key always " +
+					"comes with a new certificate which uploads a new certificate.",
+				Required:  true,
+				Sensitive: true,
+				WriteOnly: true,
+			},
+			"description": schema.StringAttribute{
+				Description: "The description of the certificate.",
+				Optional:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"validate_chain": schema.BoolAttribute{
+				Description: "Whether to validate the certificate chain is complete and the certificate " +
+					"has not expired before it is uploaded. The chain is complete if each certificate is " +
+					"signed by the next one, and the last one is self-signed or issued by a root trusted " +
+					"by the system. Default to `true`.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(true),
+			},
+			"cert_name": schema.StringAttribute{
+				Description: "The name of the certificate.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"status": schema.StringAttribute{
+				Description: "The status of the certificate, e.g. `running`, `expired` or `expiring_soon`.",
+				Computed:    true,
+			},
+			"common_name": schema.StringAttribute{
+				Description: "The common name of the subject of the certificate.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"subject_alternative_names": schema.ListAttribute{
+				Description: "The DNS names and IP addresses of the certificate.",
+				Computed:    true,
+				ElementType: types.StringType,
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"fingerprint": schema.StringAttribute{
+				Description: "The SHA-256 fingerprint of the certificate in hex.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"effective_time": schema.StringAttribute{
+				Description: "The time the certificate becomes valid in RFC3339 format.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"expire_time": schema.StringAttribute{
+				Description: "The expiry time of the certificate in RFC3339 format.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"client_config": resourceClientConfigBlock("CDN certificate", "manage CDN certificates"),
+		},
+	}
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *cdnCertificateResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	r.clients = req.ProviderData.(byteplusClients).clients
+}
+
+// ValidateConfig validates the certificate can be parsed and the private key
+// matches the certificate. The chain and the expiry are validated when the
+// certificate is uploaded, so an expiring certificate never fails the plan to
+// replace it.
+func (r *cdnCertificateResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config *cdnCertificateResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if config.Certificate.IsNull() || config.Certificate.IsUnknown() {
+		return
+	}
+
+	if _, err := parseCertificateChain(config.Certificate.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("certificate"),
+			"Invalid Certificate",
+			err.Error(),
+		)
+		return
+	}
+
+	if config.PrivateKey.IsNull() || config.PrivateKey.IsUnknown() {
+		return
+	}
+
+	if err := validateCertificateKeyPair(config.Certificate.ValueString(), config.PrivateKey.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("private_key"),
+			"Invalid Private Key",
+			err.Error(),
+		)
+	}
+}
+
+// Create validates and uploads the certificate.
+func (r *cdnCertificateResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan *cdnCertificateResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(plan.ClientConfig.setWriteOnlyKeys(ctx, req.Config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, clientDiags := r.clients.cdnClient(plan.ClientConfig.toClientConfig())
+	resp.Diagnostics.Append(clientDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The private key is write-only, so it is only available in the config.
+	var privateKey types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("private_key"), &privateKey)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	certificate := plan.Certificate.ValueString()
+	certs, err := parseCertificateChain(certificate)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("certificate"),
+			"Invalid Certificate",
+			err.Error(),
+		)
+		return
+	}
+	if err := validateCertificateKeyPair(certificate, privateKey.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("private_key"),
+			"Invalid Private Key",
+			err.Error(),
+		)
+		return
+	}
+	if plan.ValidateChain.ValueBool() {
+		if err := validateCertificateChain(certs, time.Now()); err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("certificate"),
+				"Invalid Certificate Chain",
+				err.Error(),
+			)
+			return
+		}
+	}
+
+	addCdnCertificateRequest := &byteplusCdnClient.AddCdnCertificateRequest{
+		Certificate: byteplusCdnClient.Certificate{
+			Certificate: byteplusCdnClient.GetStrPtr(certificate),
+			PrivateKey:  byteplusCdnClient.GetStrPtr(privateKey.ValueString()),
+		},
+		Source: byteplusCdnClient.GetStrPtr(cdnCertSourceCdn),
+	}
+	if description := plan.Description.ValueString(); description != "" {
+		addCdnCertificateRequest.CertInfo = &byteplusCdnClient.AddCdnCertInfo{
+			Desc: byteplusCdnClient.GetStrPtr(description),
+		}
+	}
+
+	var response *byteplusCdnClient.AddCdnCertificateResponse
+	err = retryCdnApiCall(ctx, client, "AddCdnCertificate", func() (err error) {
+		response, err = client.AddCdnCertificate(addCdnCertificateRequest)
+		return
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"[API ERROR] Failed to Add CDN Certificate.",
+			err.Error(),
+		)
+		return
+	}
+
+	leaf := certs[0]
+	state := *plan
+	state.Id = types.StringValue(response.Result)
+	state.CommonName = types.StringValue(leaf.Subject.CommonName)
+	state.Fingerprint = types.StringValue(certificateFingerprint(leaf))
+	state.EffectiveTime = types.StringValue(leaf.NotBefore.UTC().Format(time.RFC3339))
+	state.ExpireTime = types.StringValue(leaf.NotAfter.UTC().Format(time.RFC3339))
+	subjectAlternativeNames, diags := types.ListValueFrom(ctx, types.StringType, certificateSubjectAlternativeNames(leaf))
+	resp.Diagnostics.Append(diags...)
+	state.SubjectAlternativeNames = subjectAlternativeNames
+	state.CertName = types.StringNull()
+	state.Status = types.StringNull()
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	cert, err := describeCdnCertificate(ctx, client, state.Id.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"[API ERROR] Failed to Describe CDN Certificate.",
+			err.Error(),
+		)
+		return
+	}
+	if cert != nil {
+		state.CertName = types.StringValue(cert.CertName)
+		state.Status = types.StringValue(cert.Status)
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Read refreshes the name and status of the certificate, the certificate is
+// removed from state if it no longer exists.
+func (r *cdnCertificateResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state *cdnCertificateResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, clientDiags := r.clients.cdnClient(state.ClientConfig.toClientConfig())
+	resp.Diagnostics.Append(clientDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	cert, err := describeCdnCertificate(ctx, client, state.Id.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"[API ERROR] Failed to Describe CDN Certificate.",
+			err.Error(),
+		)
+		return
+	}
+	if cert == nil {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	state.CertName = types.StringValue(cert.CertName)
+	state.Status = types.StringValue(cert.Status)
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Update only changes validate_chain, the other changes upload a new
+// certificate with replacement.
+func (r *cdnCertificateResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state *cdnCertificateResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	plan.Status = state.Status
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+// Delete deletes the certificate from the certificate hosting of CDN.
+func (r *cdnCertificateResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state *cdnCertificateResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, clientDiags := r.clients.cdnClient(state.ClientConfig.toClientConfig())
+	resp.Diagnostics.Append(clientDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	certId := state.Id.ValueString()
+	err := retryCdnApiCall(ctx, client, "DeleteCdnCertificate", func() (err error) {
+		_, err = client.DeleteCdnCertificate(&byteplusCdnClient.DeleteCdnCertificateRequest{
+			CertId: certId,
+		})
+		return
+	})
+	if err != nil {
+		// The certificate may have been deleted outside of Terraform.
+		cert, describeErr := describeCdnCertificate(ctx, client, certId)
+		if describeErr == nil && cert == nil {
+			return
+		}
+
+		resp.Diagnostics.AddError(
+			"[API ERROR] Failed to Delete CDN Certificate.",
+			err.Error(),
+		)
+		return
+	}
+}
@@ -0,0 +1,826 @@
+package byteplus
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	byteplusCdnClient "github.com/byteplus-sdk/byteplus-sdk-golang/service/cdn"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+)
+
+var (
+	_ resource.Resource                   = &cdnDomainResource{}
+	_ resource.ResourceWithConfigure      = &cdnDomainResource{}
+	_ resource.ResourceWithImportState    = &cdnDomainResource{}
+	_ resource.ResourceWithModifyPlan     = &cdnDomainResource{}
+	_ resource.ResourceWithUpgradeState   = &cdnDomainResource{}
+	_ resource.ResourceWithValidateConfig = &cdnDomainResource{}
+)
+
+var (
+	cdnServiceTypes    = []string{"web", "download", "video"}
+	cdnServiceRegions  = []string{"outside_chinese_mainland", "chinese_mainland", "global"}
+	cdnOriginProtocols = []string{"http", "https", "followclient"}
+	cdnOriginTypes     = []string{"primary", "backup"}
+	cdnInstanceTypes   = []string{"ip", "domain", "tos"}
+)
+
+// The origin protocol to add the CDN domain with if origin_protocol is not
+// set.
+const cdnDefaultOriginProtocol = "http"
+
+func NewCdnDomainResource() resource.Resource {
+	return &cdnDomainResource{}
+}
+
+type cdnDomainResource struct {
+	clients            *clientFactory
+	defaultTags        map[string]string
+	defaultProjectName string
+}
+
+type cdnDomainResourceModel struct {
+	ClientConfig   *resourceClientConfig `tfsdk:"client_config"`
+	Domain         types.String          `tfsdk:"domain_name"`
+	ServiceType    types.String          `tfsdk:"service_type"`
+	ServiceRegion  types.String          `tfsdk:"service_region"`
+	OriginProtocol types.String          `tfsdk:"origin_protocol"`
+	OriginHost     types.String          `tfsdk:"origin_host"`
+	Origins        types.List            `tfsdk:"origin"`
+	ProjectName    types.String          `tfsdk:"project_name"`
+	Enabled        types.Bool            `tfsdk:"enabled"`
+	Tags           types.Map             `tfsdk:"tags"`
+	TagsAll        types.Map             `tfsdk:"tags_all"`
+	Cname          types.String          `tfsdk:"cname"`
+	Status         types.String          `tfsdk:"status"`
+}
+
+type cdnDomainOriginModel struct {
+	Address      types.String `tfsdk:"address"`
+	InstanceType types.String `tfsdk:"instance_type"`
+	OriginType   types.String `tfsdk:"origin_type"`
+	HttpPort     types.Int64  `tfsdk:"http_port"`
+	HttpsPort    types.Int64  `tfsdk:"https_port"`
+	Weight       types.Int64  `tfsdk:"weight"`
+	OriginHost   types.String `tfsdk:"origin_host"`
+}
+
+var cdnDomainOriginType = types.ObjectType{
+	AttrTypes: map[string]attr.Type{
+		"address":       types.StringType,
+		"instance_type": types.StringType,
+		"origin_type":   types.StringType,
+		"http_port":     types.Int64Type,
+		"https_port":    types.Int64Type,
+		"weight":        types.Int64Type,
+		"origin_host":   types.StringType,
+	},
+}
+
+func (r *cdnDomainResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_cdn_domain"
+}
+
+func (r *cdnDomainResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Version: 1,
+		Description: "Provides a CDN domain resource. The domain is added with its origin configurations and " +
+			"waited until it is online, and it is stopped before it is deleted. The origins are only " +
+			"required to add the domain, the origin configurations that are not set are read from the " +
+			"domain and never updated, e.g. when they are managed by `st-byteplus_cdn_domain_origin`.",
+		Attributes: map[string]schema.Attribute{
+			"domain_name": schema.StringAttribute{
+				Description: "Domain name of CDN domain.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"service_type": schema.StringAttribute{
+				Description: "The service type of CDN domain, valid values are `web`, `download` and `video`.",
+				Required:    true,
+			},
+			"service_region": schema.StringAttribute{
+				Description: "The service region of CDN domain, valid values are `outside_chinese_mainland`, " +
+					"`chinese_mainland` and `global`. Default to `outside_chinese_mainland`.",
+				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString("outside_chinese_mainland"),
+			},
+			"origin_protocol": schema.StringAttribute{
+				Description: "The protocol to fetch from the origins, valid values are `http`, `https` and " +
+					"`followclient`. The domain is added with `http` if not set, and the protocol of the " +
+					"domain is kept afterwards.",
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"origin_host": schema.StringAttribute{
+				Description: "The Host header to fetch from the origins. Default to the domain name when " +
+					"the domain is added, and the Host header of the domain is kept afterwards if not set.",
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"origin": schema.ListNestedAttribute{
+				Description: "The origins of CDN domain, at least one primary origin is required. The " +
+					"origins are required to add the domain, and the origins of the domain are kept " +
+					"afterwards if not set.",
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.UseStateForUnknown(),
+				},
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"address": schema.StringAttribute{
+							Description: "The IP address or domain name of the origin.",
+							Required:    true,
+						},
+						"instance_type": schema.StringAttribute{
+							Description: "The type of the origin address, valid values are `ip`, `domain` and `tos`.",
+							Required:    true,
+						},
+						"origin_type": schema.StringAttribute{
+							Description: "Whether the origin is a `primary` or `backup` origin. Default to `primary`.",
+							Optional:    true,
+							Computed:    true,
+							Default:     stringdefault.StaticString("primary"),
+						},
+						"http_port": schema.Int64Attribute{
+							Description: "The port to fetch from the origin in HTTP. Default to 80.",
+							Optional:    true,
+							Computed:    true,
+							Default:     int64default.StaticInt64(80),
+						},
+						"https_port": schema.Int64Attribute{
+							Description: "The port to fetch from the origin in HTTPS. Default to 443.",
+							Optional:    true,
+							Computed:    true,
+							Default:     int64default.StaticInt64(443),
+						},
+						"weight": schema.Int64Attribute{
+							Description: "The weight of the origin among the origins of the same type, " +
+								"between 1 and 100. Default to 1.",
+							Optional: true,
+							Computed: true,
+							Default:  int64default.StaticInt64(1),
+						},
+						"origin_host": schema.StringAttribute{
+							Description: "The Host header to fetch from the origin, overrides `origin_host` " +
+								"of the domain.",
+							Optional: true,
+						},
+					},
+				},
+			},
+			"project_name": schema.StringAttribute{
+				Description: "The project of CDN domain. Default to `default_project_name` of the provider, " +
+					"or the default project of the account. Changing the project recreates the domain.",
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"enabled": schema.BoolAttribute{
+				Description: "Whether the CDN domain is started. Default to true.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(true),
+			},
+			"tags": schema.MapAttribute{
+				Description: "The tags of CDN domain.",
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+			"tags_all": schema.MapAttribute{
+				Description: "The tags of CDN domain merged into `default_tags` of the provider.",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+			"cname": schema.StringAttribute{
+				Description: "Domain CName of CDN domain.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"status": schema.StringAttribute{
+				Description: "Status of CDN domain.",
+				Computed:    true,
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"client_config": resourceClientConfigBlock("CDN domain", "manage CDN domains"),
+		},
+	}
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *cdnDomainResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	r.clients = req.ProviderData.(byteplusClients).clients
+	r.defaultTags = req.ProviderData.(byteplusClients).defaultTags
+	r.defaultProjectName = req.ProviderData.(byteplusClients).defaultProjectName
+}
+
+// UpgradeState upgrades the state of version 0, where origin was a block.
+// The state of a block and a nested attribute is encoded the same, so the
+// prior state is kept as it is.
+func (r *cdnDomainResource) UpgradeState(_ context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		0: {
+			StateUpgrader: func(_ context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+				resp.DynamicValue = &tfprotov6.DynamicValue{JSON: req.RawState.JSON}
+			},
+		},
+	}
+}
+
+// ValidateConfig validates the values of the enumerations and the origins.
+func (r *cdnDomainResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config *cdnDomainResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	validateStringInSlice(&resp.Diagnostics, path.Root("service_type"), config.ServiceType, cdnServiceTypes)
+	validateStringInSlice(&resp.Diagnostics, path.Root("service_region"), config.ServiceRegion, cdnServiceRegions)
+	validateStringInSlice(&resp.Diagnostics, path.Root("origin_protocol"), config.OriginProtocol, cdnOriginProtocols)
+
+	if config.Origins.IsNull() || config.Origins.IsUnknown() {
+		return
+	}
+	for _, origin := range config.Origins.Elements() {
+		if origin.IsUnknown() {
+			return
+		}
+	}
+
+	origins, diags := cdnDomainOriginsValue(ctx, config.Origins)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	validateCdnDomainOrigins(&resp.Diagnostics, origins)
+}
+
+// ModifyPlan sets the effective tags and the default project in the plan,
+// and requires the origins to add the domain.
+func (r *cdnDomainResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	modifyPlanTagsAll(ctx, r.defaultTags, req, resp)
+	modifyPlanProjectName(ctx, r.defaultProjectName, req, resp)
+	if resp.Diagnostics.HasError() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	if req.State.Raw.IsNull() {
+		var origins types.List
+		resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("origin"), &origins)...)
+		if !resp.Diagnostics.HasError() && origins.IsNull() {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("origin"),
+				"Missing Origin",
+				"The origins are required to add the CDN domain.",
+			)
+		}
+		return
+	}
+
+	// The default project is set after the plan modifiers of the attribute,
+	// so the replacement must be required here.
+	var stateProjectName, planProjectName types.String
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("project_name"), &stateProjectName)...)
+	resp.Diagnostics.Append(resp.Plan.GetAttribute(ctx, path.Root("project_name"), &planProjectName)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !planProjectName.IsUnknown() && !planProjectName.Equal(stateProjectName) {
+		resp.RequiresReplace = append(resp.RequiresReplace, path.Root("project_name"))
+	}
+}
+
+// Create adds the CDN domain and waits until it is online.
+func (r *cdnDomainResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan *cdnDomainResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(plan.ClientConfig.setWriteOnlyKeys(ctx, req.Config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, clientDiags := r.clients.cdnClient(plan.ClientConfig.toClientConfig())
+	resp.Diagnostics.Append(clientDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	domainName := plan.Domain.ValueString()
+	tagsAll := make(map[string]string)
+	resp.Diagnostics.Append(plan.TagsAll.ElementsAs(ctx, &tagsAll, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	origins, diags := cdnDomainOriginsValue(ctx, plan.Origins)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	originProtocol := cdnDefaultOriginProtocol
+	if !plan.OriginProtocol.IsUnknown() {
+		originProtocol = plan.OriginProtocol.ValueString()
+	}
+
+	addCdnDomainRequest := &byteplusCdnClient.AddCdnDomainRequest{
+		Domain:         domainName,
+		ServiceType:    byteplusCdnClient.GetStrPtr(plan.ServiceType.ValueString()),
+		ServiceRegion:  byteplusCdnClient.GetStrPtr(plan.ServiceRegion.ValueString()),
+		OriginProtocol: originProtocol,
+		OriginHost:     stringPtrOrNil(plan.OriginHost),
+		Origin:         expandCdnDomainOrigins(origins),
+		Project:        stringPtrOrNil(plan.ProjectName),
+		ResourceTags:   cdnResourceTags(tagsAll),
+	}
+
+	err := retryCdnApiCall(ctx, client, "AddCdnDomain", func() (err error) {
+		_, err = client.AddCdnDomain(addCdnDomainRequest)
+		return
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"[API ERROR] Failed to Add CDN Domain.",
+			err.Error(),
+		)
+		return
+	}
+
+	// Save the domain to state first, so the domain is still managed if
+	// waiting for the status fails.
+	state := *plan
+	state.Status = types.StringValue(cdnDomainStatusConfiguring)
+	state.OriginProtocol = types.StringValue(originProtocol)
+	if state.OriginHost.IsUnknown() {
+		state.OriginHost = types.StringNull()
+	}
+	if state.ProjectName.IsUnknown() {
+		state.ProjectName = types.StringNull()
+	}
+	state.Cname = types.StringNull()
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	_, err = waitForCdnDomainStatus(ctx, client, domainName, cdnDomainStatusOnline, cdnDomainStatusTimeout)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"[API ERROR] Failed to Wait for CDN Domain Online.",
+			err.Error(),
+		)
+		return
+	}
+
+	if !plan.Enabled.ValueBool() {
+		resp.Diagnostics.Append(r.setEnabled(ctx, client, domainName, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	resp.Diagnostics.Append(r.readDomain(ctx, client, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Read refreshes the CDN domain, the domain is removed from state if it no
+// longer exists.
+func (r *cdnDomainResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state *cdnDomainResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, clientDiags := r.clients.cdnClient(state.ClientConfig.toClientConfig())
+	resp.Diagnostics.Append(clientDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	domain, err := describeCdnDomain(ctx, client, state.Domain.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"[API ERROR] Failed to Describe CDN Domain.",
+			err.Error(),
+		)
+		return
+	}
+	if domain == nil {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	resp.Diagnostics.Append(r.readDomain(ctx, client, state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Update updates the configurations, tags and status of the CDN domain.
+func (r *cdnDomainResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state *cdnDomainResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(plan.ClientConfig.setWriteOnlyKeys(ctx, req.Config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, clientDiags := r.clients.cdnClient(plan.ClientConfig.toClientConfig())
+	resp.Diagnostics.Append(clientDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	domainName := plan.Domain.ValueString()
+
+	// The origin configurations that are not set keep the values in state,
+	// so they are only sent when they are changed in the configuration.
+	updateCdnConfigRequest := &byteplusCdnClient.UpdateCdnConfigRequest{
+		Domain: byteplusCdnClient.GetStrPtr(domainName),
+	}
+	updateConfig := false
+	if !plan.ServiceType.Equal(state.ServiceType) || !plan.ServiceRegion.Equal(state.ServiceRegion) {
+		updateCdnConfigRequest.ServiceType = byteplusCdnClient.GetStrPtr(plan.ServiceType.ValueString())
+		updateCdnConfigRequest.ServiceRegion = byteplusCdnClient.GetStrPtr(plan.ServiceRegion.ValueString())
+		updateConfig = true
+	}
+	if !plan.OriginProtocol.IsUnknown() && !plan.OriginProtocol.Equal(state.OriginProtocol) {
+		updateCdnConfigRequest.OriginProtocol = byteplusCdnClient.GetStrPtr(plan.OriginProtocol.ValueString())
+		updateConfig = true
+	}
+	if !plan.OriginHost.IsUnknown() && !plan.OriginHost.Equal(state.OriginHost) {
+		updateCdnConfigRequest.OriginHost = byteplusCdnClient.GetStrPtr(plan.OriginHost.ValueString())
+		updateConfig = true
+	}
+	if !plan.Origins.IsUnknown() && !plan.Origins.Equal(state.Origins) {
+		origins, diags := cdnDomainOriginsValue(ctx, plan.Origins)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		updateCdnConfigRequest.Origin = expandCdnDomainOrigins(origins)
+		updateConfig = true
+	}
+
+	if updateConfig {
+		resp.Diagnostics.Append(updateCdnDomainConfig(ctx, client, updateCdnConfigRequest)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	if !plan.TagsAll.Equal(state.TagsAll) {
+		oldTags := make(map[string]string)
+		newTags := make(map[string]string)
+		resp.Diagnostics.Append(state.TagsAll.ElementsAs(ctx, &oldTags, false)...)
+		resp.Diagnostics.Append(plan.TagsAll.ElementsAs(ctx, &newTags, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		if err := updateCdnDomainTags(ctx, client, domainName, oldTags, newTags); err != nil {
+			resp.Diagnostics.AddError(
+				"[API ERROR] Failed to Update CDN Domain Tags.",
+				err.Error(),
+			)
+			return
+		}
+	}
+
+	if !plan.Enabled.Equal(state.Enabled) {
+		resp.Diagnostics.Append(r.setEnabled(ctx, client, domainName, plan.Enabled.ValueBool())...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	newState := *plan
+	resp.Diagnostics.Append(r.readDomain(ctx, client, &newState)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &newState)...)
+}
+
+// Delete stops the CDN domain and deletes it.
+func (r *cdnDomainResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state *cdnDomainResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, clientDiags := r.clients.cdnClient(state.ClientConfig.toClientConfig())
+	resp.Diagnostics.Append(clientDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	domainName := state.Domain.ValueString()
+	domain, err := describeCdnDomain(ctx, client, domainName)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"[API ERROR] Failed to Describe CDN Domain.",
+			err.Error(),
+		)
+		return
+	}
+	if domain == nil {
+		return
+	}
+
+	// Only offline domains can be deleted.
+	if domain.Status != cdnDomainStatusOffline {
+		resp.Diagnostics.Append(r.setEnabled(ctx, client, domainName, false)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	err = retryCdnApiCall(ctx, client, "DeleteCdnDomain", func() (err error) {
+		_, err = client.DeleteCdnDomain(&byteplusCdnClient.DeleteCdnDomainRequest{
+			Domain: domainName,
+		})
+		return
+	})
+	if err != nil && !isCdnDomainNotFoundError(err) {
+		resp.Diagnostics.AddError(
+			"[API ERROR] Failed to Delete CDN Domain.",
+			err.Error(),
+		)
+		return
+	}
+
+	if err = waitForCdnDomainDeleted(ctx, client, domainName, cdnDomainStatusTimeout); err != nil {
+		resp.Diagnostics.AddError(
+			"[API ERROR] Failed to Wait for CDN Domain Deleted.",
+			err.Error(),
+		)
+	}
+}
+
+// ImportState imports the CDN domain by the domain name.
+func (r *cdnDomainResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("domain_name"), req, resp)
+}
+
+// setEnabled starts or stops the CDN domain and waits until it is online or
+// offline.
+//
+// Parameters:
+//   - ctx: Context.
+//   - client: The CDN client.
+//   - domainName: The name of the CDN domain.
+//   - enabled: Whether to start or stop the CDN domain.
+//
+// Returns:
+//   - diags: Diagnostics of starting or stopping the CDN domain.
+func (r *cdnDomainResource) setEnabled(ctx context.Context, client *byteplusCdnClient.CDN, domainName string, enabled bool) (diags diag.Diagnostics) {
+	action, status := "StopCdnDomain", cdnDomainStatusOffline
+	call := func() (err error) {
+		_, err = client.StopCdnDomain(&byteplusCdnClient.StopCdnDomainRequest{Domain: domainName})
+		return
+	}
+	if enabled {
+		action, status = "StartCdnDomain", cdnDomainStatusOnline
+		call = func() (err error) {
+			_, err = client.StartCdnDomain(&byteplusCdnClient.StartCdnDomainRequest{Domain: domainName})
+			return
+		}
+	}
+
+	if err := retryCdnApiCall(ctx, client, action, call); err != nil {
+		diags.AddError(
+			fmt.Sprintf("[API ERROR] Failed to %s.", action),
+			err.Error(),
+		)
+		return
+	}
+
+	if _, err := waitForCdnDomainStatus(ctx, client, domainName, status, cdnDomainStatusTimeout); err != nil {
+		diags.AddError(
+			fmt.Sprintf("[API ERROR] Failed to Wait for CDN Domain %s.", status),
+			err.Error(),
+		)
+	}
+
+	return
+}
+
+// readDomain sets the state of the CDN domain from ListCdnDomains and
+// DescribeCdnConfig API.
+//
+// Parameters:
+//   - ctx: Context.
+//   - client: The CDN client.
+//   - state: The state to set, domain_name must be set.
+//
+// Returns:
+//   - diags: Diagnostics of describing the CDN domain.
+func (r *cdnDomainResource) readDomain(ctx context.Context, client *byteplusCdnClient.CDN, state *cdnDomainResourceModel) (diags diag.Diagnostics) {
+	domainName := state.Domain.ValueString()
+	domain, err := describeCdnDomain(ctx, client, domainName)
+	if err == nil && domain == nil {
+		err = fmt.Errorf("CDN domain %s is not found", domainName)
+	}
+	if err != nil {
+		diags.AddError(
+			"[API ERROR] Failed to Describe CDN Domain.",
+			err.Error(),
+		)
+		return
+	}
+
+	config, err := describeCdnDomainConfig(ctx, client, domainName)
+	if err != nil {
+		diags.AddError(
+			"[API ERROR] Failed to Describe CDN Domain Config.",
+			err.Error(),
+		)
+		return
+	}
+
+	state.ServiceType = types.StringValue(domain.ServiceType)
+	state.ServiceRegion = types.StringValue(domain.ServiceRegion)
+	state.OriginProtocol = types.StringValue(config.OriginProtocol)
+	if config.OriginHost != nil && *config.OriginHost != "" {
+		state.OriginHost = types.StringValue(*config.OriginHost)
+	} else {
+		state.OriginHost = types.StringNull()
+	}
+	state.Origins, diags = types.ListValueFrom(ctx, cdnDomainOriginType, flattenCdnDomainOrigins(config.Origin))
+	if diags.HasError() {
+		return
+	}
+	state.ProjectName = types.StringValue(domain.Project)
+	state.Enabled = types.BoolValue(domain.Status != cdnDomainStatusOffline)
+	state.Cname = types.StringValue(domain.Cname)
+	state.Status = types.StringValue(domain.Status)
+
+	tagsAll := cdnTagsMap(domain.ResourceTags)
+	state.TagsAll, diags = types.MapValueFrom(ctx, types.StringType, tagsAll)
+	if diags.HasError() {
+		return
+	}
+
+	// Only the tags configured in the resource are kept in tags, the other
+	// tags are either default tags or added outside of Terraform, which are
+	// shown in tags_all.
+	if !state.Tags.IsNull() && !state.Tags.IsUnknown() {
+		tags := make(map[string]string)
+		for key := range state.Tags.Elements() {
+			if value, ok := tagsAll[key]; ok {
+				tags[key] = value
+			}
+		}
+		state.Tags, diags = types.MapValueFrom(ctx, types.StringType, tags)
+	}
+
+	return
+}
+
+// validateCdnDomainOrigins validates the values of the enumerations and the
+// weights of the origins, and at least one of them is a primary origin.
+func validateCdnDomainOrigins(diags *diag.Diagnostics, origins []*cdnDomainOriginModel) {
+	hasPrimaryOrigin := false
+	for i, origin := range origins {
+		originPath := path.Root("origin").AtListIndex(i)
+		validateStringInSlice(diags, originPath.AtName("instance_type"), origin.InstanceType, cdnInstanceTypes)
+		validateStringInSlice(diags, originPath.AtName("origin_type"), origin.OriginType, cdnOriginTypes)
+
+		if !origin.Weight.IsNull() && !origin.Weight.IsUnknown() {
+			if weight := origin.Weight.ValueInt64(); weight < 1 || weight > 100 {
+				diags.AddAttributeError(
+					originPath.AtName("weight"),
+					"Invalid Origin Weight",
+					fmt.Sprintf("The weight of the origin must be between 1 and 100, got %d.", weight),
+				)
+			}
+		}
+
+		if origin.OriginType.IsNull() || origin.OriginType.IsUnknown() || origin.OriginType.ValueString() == "primary" {
+			hasPrimaryOrigin = true
+		}
+	}
+
+	if !hasPrimaryOrigin {
+		diags.AddAttributeError(
+			path.Root("origin"),
+			"Missing Primary Origin",
+			"At least one primary origin is required for CDN domain.",
+		)
+	}
+}
+
+// expandCdnDomainOrigins converts the origins to the origin rule of CDN API.
+func expandCdnDomainOrigins(origins []*cdnDomainOriginModel) []byteplusCdnClient.OriginRule {
+	originLines := make([]byteplusCdnClient.OriginLine, 0, len(origins))
+	for _, origin := range origins {
+		originLines = append(originLines, byteplusCdnClient.OriginLine{
+			Address:      byteplusCdnClient.GetStrPtr(origin.Address.ValueString()),
+			InstanceType: byteplusCdnClient.GetStrPtr(origin.InstanceType.ValueString()),
+			OriginType:   byteplusCdnClient.GetStrPtr(origin.OriginType.ValueString()),
+			HttpPort:     byteplusCdnClient.GetStrPtr(strconv.FormatInt(origin.HttpPort.ValueInt64(), 10)),
+			HttpsPort:    byteplusCdnClient.GetStrPtr(strconv.FormatInt(origin.HttpsPort.ValueInt64(), 10)),
+			Weight:       byteplusCdnClient.GetStrPtr(strconv.FormatInt(origin.Weight.ValueInt64(), 10)),
+			OriginHost:   stringPtrOrNil(origin.OriginHost),
+		})
+	}
+
+	return []byteplusCdnClient.OriginRule{
+		{
+			OriginAction: &byteplusCdnClient.OriginAction{
+				OriginLines: originLines,
+			},
+		},
+	}
+}
+
+// flattenCdnDomainOrigins converts the origin rule of CDN API to the origins,
+// only the default origin rule without condition is read.
+func flattenCdnDomainOrigins(originRules []byteplusCdnClient.OriginRule) (origins []*cdnDomainOriginModel) {
+	for _, originRule := range originRules {
+		if originRule.Condition != nil || originRule.OriginAction == nil {
+			continue
+		}
+
+		for _, originLine := range originRule.OriginAction.OriginLines {
+			origins = append(origins, &cdnDomainOriginModel{
+				Address:      types.StringValue(stringValue(originLine.Address)),
+				InstanceType: types.StringValue(stringValue(originLine.InstanceType)),
+				OriginType:   types.StringValue(stringValue(originLine.OriginType)),
+				HttpPort:     types.Int64Value(int64Value(originLine.HttpPort)),
+				HttpsPort:    types.Int64Value(int64Value(originLine.HttpsPort)),
+				Weight:       types.Int64Value(int64Value(originLine.Weight)),
+				OriginHost:   stringValueOrNull(originLine.OriginHost),
+			})
+		}
+		break
+	}
+
+	return
+}
+
+// cdnDomainOriginsValue converts the list of the origin attribute to the
+// origins, the list must be known.
+func cdnDomainOriginsValue(ctx context.Context, list types.List) (origins []*cdnDomainOriginModel, diags diag.Diagnostics) {
+	if list.IsNull() {
+		return nil, diags
+	}
+
+	diags = list.ElementsAs(ctx, &origins, false)
+	return
+}
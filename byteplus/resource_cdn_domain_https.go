@@ -0,0 +1,486 @@
+package byteplus
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/byteplus-sdk/byteplus-go-sdk-v2/byteplus"
+
+	byteplusCdnClient "github.com/byteplus-sdk/byteplus-sdk-golang/service/cdn"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ resource.Resource                   = &cdnDomainHttpsResource{}
+	_ resource.ResourceWithConfigure      = &cdnDomainHttpsResource{}
+	_ resource.ResourceWithImportState    = &cdnDomainHttpsResource{}
+	_ resource.ResourceWithValidateConfig = &cdnDomainHttpsResource{}
+)
+
+var (
+	cdnTlsVersions                  = []string{"tlsv1.0", "tlsv1.1", "tlsv1.2", "tlsv1.3"}
+	cdnForcedRedirectStatusCodes    = []string{"301", "302"}
+	cdnHstsSubdomainInclude         = "include"
+	cdnHstsSubdomainExclude         = "exclude"
+	cdnDomainHttpsDefaultTlsVersion = []attr.Value{types.StringValue("tlsv1.2"), types.StringValue("tlsv1.3")}
+)
+
+func NewCdnDomainHttpsResource() resource.Resource {
+	return &cdnDomainHttpsResource{}
+}
+
+type cdnDomainHttpsResource struct {
+	clients *clientFactory
+}
+
+type cdnDomainHttpsResourceModel struct {
+	ClientConfig             *resourceClientConfig `tfsdk:"client_config"`
+	Domain                   types.String          `tfsdk:"domain_name"`
+	CertId                   types.String          `tfsdk:"cert_id"`
+	Http2                    types.Bool            `tfsdk:"http2"`
+	TlsVersions              types.Set             `tfsdk:"tls_versions"`
+	Ocsp                     types.Bool            `tfsdk:"ocsp"`
+	ForcedRedirect           types.Bool            `tfsdk:"forced_redirect"`
+	ForcedRedirectStatusCode types.String          `tfsdk:"forced_redirect_status_code"`
+	HstsEnabled              types.Bool            `tfsdk:"hsts_enabled"`
+	HstsMaxAge               types.Int64           `tfsdk:"hsts_max_age"`
+	HstsIncludeSubdomains    types.Bool            `tfsdk:"hsts_include_subdomains"`
+	CertName                 types.String          `tfsdk:"cert_name"`
+	CertExpireTime           types.String          `tfsdk:"cert_expire_time"`
+}
+
+func (r *cdnDomainHttpsResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_cdn_domain_https"
+}
+
+func (r *cdnDomainHttpsResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Enables HTTPS of a CDN domain with a certificate uploaded to the certificate hosting " +
+			"of CDN, and manages the TLS policy of the domain. Changing `cert_id` rotates the certificate " +
+			"in place, and HTTPS is disabled when the resource is destroyed.",
+		Attributes: map[string]schema.Attribute{
+			"domain_name": schema.StringAttribute{
+				Description: "Domain name of CDN domain.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"cert_id": schema.StringAttribute{
+				Description: "The ID of the certificate bound to the domain, e.g. the `id` of " +
+					"`st-byteplus_cdn_certificate`.",
+				Required: true,
+			},
+			"http2": schema.BoolAttribute{
+				Description: "Whether HTTP/2 is enabled. Default to `true`.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(true),
+			},
+			"tls_versions": schema.SetAttribute{
+				Description: "The TLS versions enabled, valid values are `tlsv1.0`, `tlsv1.1`, `tlsv1.2` " +
+					"and `tlsv1.3`. Default to `tlsv1.2` and `tlsv1.3`.",
+				Optional:    true,
+				Computed:    true,
+				ElementType: types.StringType,
+				Default:     setdefault.StaticValue(types.SetValueMust(types.StringType, cdnDomainHttpsDefaultTlsVersion)),
+			},
+			"ocsp": schema.BoolAttribute{
+				Description: "Whether OCSP stapling is enabled. Default to `false`.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+			},
+			"forced_redirect": schema.BoolAttribute{
+				Description: "Whether HTTP requests are redirected to HTTPS. Default to `false`.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+			},
+			"forced_redirect_status_code": schema.StringAttribute{
+				Description: "The status code of redirecting HTTP requests to HTTPS, valid values are " +
+					"`301` and `302`. Default to `301`.",
+				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString("301"),
+			},
+			"hsts_enabled": schema.BoolAttribute{
+				Description: "Whether the Strict-Transport-Security header is added to HTTPS responses. " +
+					"Default to `false`.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+			},
+			"hsts_max_age": schema.Int64Attribute{
+				Description: "The max-age of the Strict-Transport-Security header in seconds, between 0 " +
+					"and 31536000. Default to 31536000.",
+				Optional: true,
+				Computed: true,
+				Default:  int64default.StaticInt64(31536000),
+			},
+			"hsts_include_subdomains": schema.BoolAttribute{
+				Description: "Whether the Strict-Transport-Security header includes the subdomains. " +
+					"Default to `false`.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+			},
+			"cert_name": schema.StringAttribute{
+				Description: "The name of the certificate bound to the domain.",
+				Computed:    true,
+			},
+			"cert_expire_time": schema.StringAttribute{
+				Description: "The expiry time of the certificate bound to the domain in RFC3339 format.",
+				Computed:    true,
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"client_config": resourceClientConfigBlock("CDN domain", "manage CDN domains"),
+		},
+	}
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *cdnDomainHttpsResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	r.clients = req.ProviderData.(byteplusClients).clients
+}
+
+// ValidateConfig validates the TLS versions, the redirect status code and the
+// max-age of HSTS.
+func (r *cdnDomainHttpsResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config *cdnDomainHttpsResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	validateStringInSlice(&resp.Diagnostics, path.Root("forced_redirect_status_code"), config.ForcedRedirectStatusCode, cdnForcedRedirectStatusCodes)
+
+	if !config.TlsVersions.IsNull() && !config.TlsVersions.IsUnknown() {
+		if len(config.TlsVersions.Elements()) == 0 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("tls_versions"),
+				"Missing TLS Versions",
+				"At least one TLS version is required.",
+			)
+		}
+		for _, element := range config.TlsVersions.Elements() {
+			if tlsVersion, ok := element.(types.String); ok {
+				validateStringInSlice(&resp.Diagnostics, path.Root("tls_versions").AtSetValue(tlsVersion), tlsVersion, cdnTlsVersions)
+			}
+		}
+	}
+
+	if !config.HstsMaxAge.IsNull() && !config.HstsMaxAge.IsUnknown() {
+		if maxAge := config.HstsMaxAge.ValueInt64(); maxAge < 0 || maxAge > 31536000 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("hsts_max_age"),
+				"Invalid HSTS Max Age",
+				fmt.Sprintf("The max-age of HSTS must be between 0 and 31536000, got %d.", maxAge),
+			)
+		}
+	}
+}
+
+// Create enables HTTPS of the CDN domain with the certificate.
+func (r *cdnDomainHttpsResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan *cdnDomainHttpsResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(plan.ClientConfig.setWriteOnlyKeys(ctx, req.Config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, clientDiags := r.clients.cdnClient(plan.ClientConfig.toClientConfig())
+	resp.Diagnostics.Append(clientDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	https, diags := expandCdnDomainHttps(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(updateCdnDomainConfig(ctx, client, &byteplusCdnClient.UpdateCdnConfigRequest{
+		Domain: byteplusCdnClient.GetStrPtr(plan.Domain.ValueString()),
+		HTTPS:  https,
+	})...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state := *plan
+	found, diags := r.readHttps(ctx, client, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if !found {
+		resp.Diagnostics.AddError(
+			"CDN Domain HTTPS Not Enabled.",
+			fmt.Sprintf("HTTPS of CDN domain %s is still disabled after it is enabled.", plan.Domain.ValueString()),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Read refreshes the HTTPS configurations of the CDN domain to detect drift,
+// the resource is removed from state if the domain no longer exists or HTTPS
+// is disabled.
+func (r *cdnDomainHttpsResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state *cdnDomainHttpsResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, clientDiags := r.clients.cdnClient(state.ClientConfig.toClientConfig())
+	resp.Diagnostics.Append(clientDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	found, diags := r.readHttps(ctx, client, state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if !found {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Update changes the HTTPS configurations of the CDN domain, the certificate
+// is rotated in place if cert_id changes.
+func (r *cdnDomainHttpsResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan *cdnDomainHttpsResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(plan.ClientConfig.setWriteOnlyKeys(ctx, req.Config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, clientDiags := r.clients.cdnClient(plan.ClientConfig.toClientConfig())
+	resp.Diagnostics.Append(clientDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	https, diags := expandCdnDomainHttps(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(updateCdnDomainConfig(ctx, client, &byteplusCdnClient.UpdateCdnConfigRequest{
+		Domain: byteplusCdnClient.GetStrPtr(plan.Domain.ValueString()),
+		HTTPS:  https,
+	})...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state := *plan
+	_, diags = r.readHttps(ctx, client, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Delete disables HTTPS of the CDN domain.
+func (r *cdnDomainHttpsResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state *cdnDomainHttpsResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, clientDiags := r.clients.cdnClient(state.ClientConfig.toClientConfig())
+	resp.Diagnostics.Append(clientDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	domainName := state.Domain.ValueString()
+	cdnDomain, err := describeCdnDomain(ctx, client, domainName)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"[API ERROR] Failed to Describe CDN Domain.",
+			err.Error(),
+		)
+		return
+	}
+	if cdnDomain == nil {
+		return
+	}
+
+	resp.Diagnostics.Append(updateCdnDomainConfig(ctx, client, &byteplusCdnClient.UpdateCdnConfigRequest{
+		Domain: byteplusCdnClient.GetStrPtr(domainName),
+		HTTPS: &byteplusCdnClient.HTTPS{
+			Switch: byteplusCdnClient.GetBoolPtr(false),
+		},
+	})...)
+}
+
+func (r *cdnDomainHttpsResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("domain_name"), req, resp)
+}
+
+// readHttps reads the HTTPS configurations of the CDN domain into the state.
+// The max-age and subdomains of HSTS and the redirect status code are kept
+// while they are disabled, as they are not returned by the API.
+//
+// Parameters:
+//   - ctx: Context.
+//   - client: The CDN client.
+//   - state: The state to read into.
+//
+// Returns:
+//   - found: Whether the domain exists and HTTPS is enabled.
+//   - diags: Diagnostics of describing the domain.
+func (r *cdnDomainHttpsResource) readHttps(ctx context.Context, client *byteplusCdnClient.CDN, state *cdnDomainHttpsResourceModel) (found bool, diags diag.Diagnostics) {
+	domainName := state.Domain.ValueString()
+	cdnDomain, err := describeCdnDomain(ctx, client, domainName)
+	if err != nil {
+		diags.AddError(
+			"[API ERROR] Failed to Describe CDN Domain.",
+			err.Error(),
+		)
+		return
+	}
+	if cdnDomain == nil {
+		return false, diags
+	}
+
+	domainConfig, err := describeCdnDomainConfig(ctx, client, domainName)
+	if err != nil {
+		diags.AddError(
+			"[API ERROR] Failed to Describe CDN Domain Config.",
+			err.Error(),
+		)
+		return
+	}
+
+	https := domainConfig.HTTPS
+	if https == nil || !byteplus.BoolValue(https.Switch) {
+		return false, diags
+	}
+
+	state.CertId = types.StringNull()
+	state.CertName = types.StringNull()
+	state.CertExpireTime = types.StringNull()
+	if https.CertInfo != nil {
+		state.CertId = stringValueOrNull(https.CertInfo.CertId)
+		state.CertName = stringValueOrNull(https.CertInfo.CertName)
+		state.CertExpireTime = unixTimeValue(byteplus.Int64Value(https.CertInfo.ExpireTime))
+	}
+	state.Http2 = types.BoolValue(byteplus.BoolValue(https.HTTP2))
+	state.Ocsp = types.BoolValue(byteplus.BoolValue(https.OCSP))
+	state.TlsVersions, diags = types.SetValueFrom(ctx, types.StringType, https.TlsVersion)
+	if diags.HasError() {
+		return
+	}
+
+	// The attributes are not set in state after import.
+	if state.ForcedRedirectStatusCode.IsNull() {
+		state.ForcedRedirectStatusCode = types.StringValue("301")
+	}
+	if state.HstsMaxAge.IsNull() {
+		state.HstsMaxAge = types.Int64Value(31536000)
+	}
+	if state.HstsIncludeSubdomains.IsNull() {
+		state.HstsIncludeSubdomains = types.BoolValue(false)
+	}
+
+	state.ForcedRedirect = types.BoolValue(false)
+	if https.ForcedRedirect != nil && byteplus.BoolValue(https.ForcedRedirect.EnableForcedRedirect) {
+		state.ForcedRedirect = types.BoolValue(true)
+		if https.ForcedRedirect.StatusCode != nil {
+			state.ForcedRedirectStatusCode = types.StringValue(*https.ForcedRedirect.StatusCode)
+		}
+	}
+
+	state.HstsEnabled = types.BoolValue(false)
+	if https.Hsts != nil && byteplus.BoolValue(https.Hsts.Switch) {
+		state.HstsEnabled = types.BoolValue(true)
+		if https.Hsts.Ttl != nil {
+			state.HstsMaxAge = types.Int64Value(*https.Hsts.Ttl)
+		}
+		if https.Hsts.Subdomain != nil {
+			state.HstsIncludeSubdomains = types.BoolValue(*https.Hsts.Subdomain == cdnHstsSubdomainInclude)
+		}
+	}
+
+	return true, diags
+}
+
+// expandCdnDomainHttps converts the plan to the HTTPS configurations of
+// UpdateCdnConfig API.
+func expandCdnDomainHttps(ctx context.Context, plan *cdnDomainHttpsResourceModel) (https *byteplusCdnClient.HTTPS, diags diag.Diagnostics) {
+	var tlsVersions []string
+	diags = plan.TlsVersions.ElementsAs(ctx, &tlsVersions, false)
+	if diags.HasError() {
+		return
+	}
+	sort.Strings(tlsVersions)
+
+	hstsSubdomain := cdnHstsSubdomainExclude
+	if plan.HstsIncludeSubdomains.ValueBool() {
+		hstsSubdomain = cdnHstsSubdomainInclude
+	}
+
+	https = &byteplusCdnClient.HTTPS{
+		Switch: byteplusCdnClient.GetBoolPtr(true),
+		CertInfo: &byteplusCdnClient.CertInfo{
+			CertId: byteplusCdnClient.GetStrPtr(plan.CertId.ValueString()),
+		},
+		HTTP2:      byteplusCdnClient.GetBoolPtr(plan.Http2.ValueBool()),
+		OCSP:       byteplusCdnClient.GetBoolPtr(plan.Ocsp.ValueBool()),
+		TlsVersion: tlsVersions,
+		ForcedRedirect: &byteplusCdnClient.ForcedRedirect{
+			EnableForcedRedirect: byteplusCdnClient.GetBoolPtr(plan.ForcedRedirect.ValueBool()),
+			StatusCode:           byteplusCdnClient.GetStrPtr(plan.ForcedRedirectStatusCode.ValueString()),
+		},
+		Hsts: &byteplusCdnClient.Hsts{
+			Switch:    byteplusCdnClient.GetBoolPtr(plan.HstsEnabled.ValueBool()),
+			Ttl:       byteplusCdnClient.GetInt64Ptr(plan.HstsMaxAge.ValueInt64()),
+			Subdomain: byteplusCdnClient.GetStrPtr(hstsSubdomain),
+		},
+	}
+
+	return https, diags
+}
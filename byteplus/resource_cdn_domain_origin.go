@@ -0,0 +1,503 @@
+package byteplus
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/byteplus-sdk/byteplus-go-sdk-v2/byteplus"
+
+	byteplusCdnClient "github.com/byteplus-sdk/byteplus-sdk-golang/service/cdn"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+)
+
+// The key of the private state that saves the origin configurations of the
+// CDN domain before the resource is created, restored when it is destroyed.
+const cdnDomainOriginPrivateKey = "prior_origin_config"
+
+var (
+	_ resource.Resource                   = &cdnDomainOriginResource{}
+	_ resource.ResourceWithConfigure      = &cdnDomainOriginResource{}
+	_ resource.ResourceWithImportState    = &cdnDomainOriginResource{}
+	_ resource.ResourceWithUpgradeState   = &cdnDomainOriginResource{}
+	_ resource.ResourceWithValidateConfig = &cdnDomainOriginResource{}
+)
+
+func NewCdnDomainOriginResource() resource.Resource {
+	return &cdnDomainOriginResource{}
+}
+
+type cdnDomainOriginResource struct {
+	clients *clientFactory
+}
+
+type cdnDomainOriginResourceModel struct {
+	ClientConfig   *resourceClientConfig `tfsdk:"client_config"`
+	Domain         types.String          `tfsdk:"domain_name"`
+	OriginProtocol types.String          `tfsdk:"origin_protocol"`
+	OriginHost     types.String          `tfsdk:"origin_host"`
+	OriginSni      types.String          `tfsdk:"origin_sni"`
+	Origins        types.List            `tfsdk:"origin"`
+}
+
+// cdnDomainOriginConfig is the origin configurations of the CDN domain saved
+// in the private state.
+type cdnDomainOriginConfig struct {
+	OriginProtocol string
+	OriginHost     *string
+	OriginSni      *byteplusCdnClient.OriginSni
+	Origin         []byteplusCdnClient.OriginRule
+}
+
+func (r *cdnDomainOriginResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_cdn_domain_origin"
+}
+
+func (r *cdnDomainOriginResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Version: 1,
+		Description: "Manages the origins of an existing CDN domain independently of the domain, so the " +
+			"origins are updated in place without recreating the domain. Destroying the resource " +
+			"restores the origins, origin protocol, Host header and SNI of the domain before the " +
+			"resource was created, as a CDN domain cannot have no origin. An imported resource keeps " +
+			"the configurations last applied when destroyed, as the configurations before are unknown. " +
+			"Leave `origin_protocol` and `origin_host` of `st-byteplus_cdn_domain` unset, and add " +
+			"`origin` to its `ignore_changes` as the origins are required to add the domain.",
+		Attributes: map[string]schema.Attribute{
+			"domain_name": schema.StringAttribute{
+				Description: "Domain name of CDN domain.",
+				Required:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"origin_protocol": schema.StringAttribute{
+				Description: "The protocol to fetch from the origins, valid values are `http`, `https` and " +
+					"`followclient`. Default to `http`.",
+				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString("http"),
+			},
+			"origin_host": schema.StringAttribute{
+				Description: "The Host header to fetch from the origins. The Host header of the domain is " +
+					"kept if not set.",
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"origin_sni": schema.StringAttribute{
+				Description: "The SNI sent to the origins in HTTPS, e.g. when the origins serve multiple " +
+					"certificates. SNI is disabled if not set.",
+				Optional: true,
+			},
+			"origin": schema.ListNestedAttribute{
+				Description: "The origins of CDN domain, at least one primary origin is required. Requests " +
+					"are distributed among the primary origins by weight, and fall back to the backup " +
+					"origins when all the primary origins fail.",
+				Required: true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"address": schema.StringAttribute{
+							Description: "The IP address or domain name of the origin, or the bucket " +
+								"domain name of a TOS origin.",
+							Required: true,
+						},
+						"instance_type": schema.StringAttribute{
+							Description: "The type of the origin address, valid values are `ip`, `domain` and `tos`.",
+							Required:    true,
+						},
+						"origin_type": schema.StringAttribute{
+							Description: "Whether the origin is a `primary` or `backup` origin. Default to `primary`.",
+							Optional:    true,
+							Computed:    true,
+							Default:     stringdefault.StaticString("primary"),
+						},
+						"http_port": schema.Int64Attribute{
+							Description: "The port to fetch from the origin in HTTP. Default to 80.",
+							Optional:    true,
+							Computed:    true,
+							Default:     int64default.StaticInt64(80),
+						},
+						"https_port": schema.Int64Attribute{
+							Description: "The port to fetch from the origin in HTTPS. Default to 443.",
+							Optional:    true,
+							Computed:    true,
+							Default:     int64default.StaticInt64(443),
+						},
+						"weight": schema.Int64Attribute{
+							Description: "The weight of the origin among the origins of the same type, " +
+								"between 1 and 100. Default to 1.",
+							Optional: true,
+							Computed: true,
+							Default:  int64default.StaticInt64(1),
+						},
+						"origin_host": schema.StringAttribute{
+							Description: "The Host header to fetch from the origin, overrides `origin_host` " +
+								"of the domain.",
+							Optional: true,
+						},
+					},
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"client_config": resourceClientConfigBlock("CDN domain", "manage CDN domains"),
+		},
+	}
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *cdnDomainOriginResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	r.clients = req.ProviderData.(byteplusClients).clients
+}
+
+// UpgradeState upgrades the state of version 0, where origin was a block.
+// The state of a block and a nested attribute is encoded the same, so the
+// prior state is kept as it is.
+func (r *cdnDomainOriginResource) UpgradeState(_ context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		0: {
+			StateUpgrader: func(_ context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+				resp.DynamicValue = &tfprotov6.DynamicValue{JSON: req.RawState.JSON}
+			},
+		},
+	}
+}
+
+// ValidateConfig validates the origin protocol and the origins.
+func (r *cdnDomainOriginResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config *cdnDomainOriginResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	validateStringInSlice(&resp.Diagnostics, path.Root("origin_protocol"), config.OriginProtocol, cdnOriginProtocols)
+
+	if config.Origins.IsNull() || config.Origins.IsUnknown() {
+		return
+	}
+	for _, origin := range config.Origins.Elements() {
+		if origin.IsUnknown() {
+			return
+		}
+	}
+
+	origins, diags := cdnDomainOriginsValue(ctx, config.Origins)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	validateCdnDomainOrigins(&resp.Diagnostics, origins)
+}
+
+// Create saves the origin configurations of the CDN domain in the private
+// state, and replaces them with the planned origins.
+func (r *cdnDomainOriginResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan *cdnDomainOriginResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(plan.ClientConfig.setWriteOnlyKeys(ctx, req.Config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, clientDiags := r.clients.cdnClient(plan.ClientConfig.toClientConfig())
+	resp.Diagnostics.Append(clientDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	domainConfig, err := describeCdnDomainConfig(ctx, client, plan.Domain.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"[API ERROR] Failed to Describe CDN Domain Config.",
+			err.Error(),
+		)
+		return
+	}
+	priorConfig, err := json.Marshal(cdnDomainOriginConfig{
+		OriginProtocol: domainConfig.OriginProtocol,
+		OriginHost:     domainConfig.OriginHost,
+		OriginSni:      domainConfig.OriginSni,
+		Origin:         domainConfig.Origin,
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Failed to Save CDN Domain Origins.",
+			err.Error(),
+		)
+		return
+	}
+	resp.Diagnostics.Append(resp.Private.SetKey(ctx, cdnDomainOriginPrivateKey, priorConfig)...)
+
+	updateCdnConfigRequest, diags := expandCdnDomainOriginConfig(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(updateCdnDomainConfig(ctx, client, updateCdnConfigRequest)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state := *plan
+	_, diags = r.readOrigins(ctx, client, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Read refreshes the origins of the CDN domain to detect drift, the resource
+// is removed from state if the domain no longer exists.
+func (r *cdnDomainOriginResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state *cdnDomainOriginResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, clientDiags := r.clients.cdnClient(state.ClientConfig.toClientConfig())
+	resp.Diagnostics.Append(clientDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	found, diags := r.readOrigins(ctx, client, state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if !found {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Update replaces the origins of the CDN domain with the planned origins.
+func (r *cdnDomainOriginResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan *cdnDomainOriginResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(plan.ClientConfig.setWriteOnlyKeys(ctx, req.Config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, clientDiags := r.clients.cdnClient(plan.ClientConfig.toClientConfig())
+	resp.Diagnostics.Append(clientDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updateCdnConfigRequest, diags := expandCdnDomainOriginConfig(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(updateCdnDomainConfig(ctx, client, updateCdnConfigRequest)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state := *plan
+	_, diags = r.readOrigins(ctx, client, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Delete restores the origin configurations of the CDN domain saved in the
+// private state when the resource was created. An imported resource has no
+// saved configurations, so the configurations last applied are kept as a
+// domain cannot have no origin.
+func (r *cdnDomainOriginResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state *cdnDomainOriginResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	priorConfig, diags := req.Private.GetKey(ctx, cdnDomainOriginPrivateKey)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if priorConfig == nil {
+		resp.Diagnostics.AddWarning(
+			"CDN Domain Origins Kept.",
+			"The origin configurations of the CDN domain before the resource was created are unknown, "+
+				"e.g. the resource is imported, so the origin configurations last applied are kept.",
+		)
+		return
+	}
+
+	var originConfig cdnDomainOriginConfig
+	if err := json.Unmarshal(priorConfig, &originConfig); err != nil {
+		resp.Diagnostics.AddError(
+			"Failed to Restore CDN Domain Origins.",
+			err.Error(),
+		)
+		return
+	}
+
+	client, clientDiags := r.clients.cdnClient(state.ClientConfig.toClientConfig())
+	resp.Diagnostics.Append(clientDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	domainName := state.Domain.ValueString()
+	cdnDomain, err := describeCdnDomain(ctx, client, domainName)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"[API ERROR] Failed to Describe CDN Domain.",
+			err.Error(),
+		)
+		return
+	}
+	if cdnDomain == nil {
+		return
+	}
+
+	resp.Diagnostics.Append(updateCdnDomainConfig(ctx, client, expandCdnDomainPriorOriginConfig(domainName, originConfig))...)
+}
+
+func (r *cdnDomainOriginResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("domain_name"), req, resp)
+}
+
+// readOrigins reads the origin configurations of the CDN domain into the
+// state.
+//
+// Parameters:
+//   - ctx: Context.
+//   - client: The CDN client.
+//   - state: The state to read into.
+//
+// Returns:
+//   - found: Whether the domain exists.
+//   - diags: Diagnostics of describing the domain.
+func (r *cdnDomainOriginResource) readOrigins(ctx context.Context, client *byteplusCdnClient.CDN, state *cdnDomainOriginResourceModel) (found bool, diags diag.Diagnostics) {
+	domainName := state.Domain.ValueString()
+	cdnDomain, err := describeCdnDomain(ctx, client, domainName)
+	if err != nil {
+		diags.AddError(
+			"[API ERROR] Failed to Describe CDN Domain.",
+			err.Error(),
+		)
+		return
+	}
+	if cdnDomain == nil {
+		return false, diags
+	}
+
+	domainConfig, err := describeCdnDomainConfig(ctx, client, domainName)
+	if err != nil {
+		diags.AddError(
+			"[API ERROR] Failed to Describe CDN Domain Config.",
+			err.Error(),
+		)
+		return
+	}
+
+	state.OriginProtocol = types.StringValue(domainConfig.OriginProtocol)
+	if domainConfig.OriginHost != nil && *domainConfig.OriginHost != "" {
+		state.OriginHost = types.StringValue(*domainConfig.OriginHost)
+	} else {
+		state.OriginHost = types.StringNull()
+	}
+	state.OriginSni = types.StringNull()
+	if domainConfig.OriginSni != nil && byteplus.BoolValue(domainConfig.OriginSni.Switch) {
+		state.OriginSni = stringValueOrNull(domainConfig.OriginSni.SniDomain)
+	}
+	state.Origins, diags = types.ListValueFrom(ctx, cdnDomainOriginType, flattenCdnDomainOrigins(domainConfig.Origin))
+
+	return !diags.HasError(), diags
+}
+
+// expandCdnDomainOriginConfig converts the plan to the origin configurations
+// of UpdateCdnConfig API, the Host header of the domain is kept if it is not
+// set.
+func expandCdnDomainOriginConfig(ctx context.Context, plan *cdnDomainOriginResourceModel) (updateCdnConfigRequest *byteplusCdnClient.UpdateCdnConfigRequest, diags diag.Diagnostics) {
+	origins, diags := cdnDomainOriginsValue(ctx, plan.Origins)
+	if diags.HasError() {
+		return
+	}
+
+	originSni := &byteplusCdnClient.OriginSni{
+		Switch: byteplusCdnClient.GetBoolPtr(false),
+	}
+	if plan.OriginSni.ValueString() != "" {
+		originSni = &byteplusCdnClient.OriginSni{
+			Switch:    byteplusCdnClient.GetBoolPtr(true),
+			SniDomain: byteplusCdnClient.GetStrPtr(plan.OriginSni.ValueString()),
+		}
+	}
+
+	updateCdnConfigRequest = &byteplusCdnClient.UpdateCdnConfigRequest{
+		Domain:         byteplusCdnClient.GetStrPtr(plan.Domain.ValueString()),
+		OriginProtocol: byteplusCdnClient.GetStrPtr(plan.OriginProtocol.ValueString()),
+		OriginSni:      originSni,
+		Origin:         expandCdnDomainOrigins(origins),
+	}
+	if !plan.OriginHost.IsNull() && !plan.OriginHost.IsUnknown() {
+		updateCdnConfigRequest.OriginHost = byteplusCdnClient.GetStrPtr(plan.OriginHost.ValueString())
+	}
+
+	return updateCdnConfigRequest, diags
+}
+
+// expandCdnDomainPriorOriginConfig converts the origin configurations saved
+// in the private state to the request of UpdateCdnConfig API, the Host header
+// and SNI not set before are cleared.
+func expandCdnDomainPriorOriginConfig(domainName string, originConfig cdnDomainOriginConfig) *byteplusCdnClient.UpdateCdnConfigRequest {
+	updateCdnConfigRequest := &byteplusCdnClient.UpdateCdnConfigRequest{
+		Domain:         byteplusCdnClient.GetStrPtr(domainName),
+		OriginProtocol: byteplusCdnClient.GetStrPtr(originConfig.OriginProtocol),
+		OriginHost:     originConfig.OriginHost,
+		OriginSni:      originConfig.OriginSni,
+		Origin:         originConfig.Origin,
+	}
+	if updateCdnConfigRequest.OriginHost == nil {
+		updateCdnConfigRequest.OriginHost = byteplusCdnClient.GetStrPtr("")
+	}
+	if updateCdnConfigRequest.OriginSni == nil {
+		updateCdnConfigRequest.OriginSni = &byteplusCdnClient.OriginSni{
+			Switch: byteplusCdnClient.GetBoolPtr(false),
+		}
+	}
+
+	return updateCdnConfigRequest
+}
@@ -0,0 +1,73 @@
+package byteplus
+
+import (
+	"context"
+	"testing"
+
+	byteplusCdnClient "github.com/byteplus-sdk/byteplus-sdk-golang/service/cdn"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestExpandCdnDomainOriginConfig(t *testing.T) {
+	ctx := context.Background()
+
+	origins, diags := types.ListValueFrom(ctx, cdnDomainOriginType, []*cdnDomainOriginModel{
+		{
+			Address:      types.StringValue("1.1.1.1"),
+			InstanceType: types.StringValue("ip"),
+			OriginType:   types.StringValue("primary"),
+			HttpPort:     types.Int64Value(80),
+			HttpsPort:    types.Int64Value(443),
+			Weight:       types.Int64Value(1),
+			OriginHost:   types.StringNull(),
+		},
+	})
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	tests := []struct {
+		name           string
+		originHost     types.String
+		wantOriginHost *string
+	}{
+		{name: "origin host set", originHost: types.StringValue("origin.example.com"), wantOriginHost: byteplusCdnClient.GetStrPtr("origin.example.com")},
+		{name: "origin host kept if null", originHost: types.StringNull()},
+		{name: "origin host kept if unknown", originHost: types.StringUnknown()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			request, diags := expandCdnDomainOriginConfig(ctx, &cdnDomainOriginResourceModel{
+				Domain:         types.StringValue("www.example.com"),
+				OriginProtocol: types.StringValue("http"),
+				OriginHost:     tt.originHost,
+				OriginSni:      types.StringNull(),
+				Origins:        origins,
+			})
+			if diags.HasError() {
+				t.Fatalf("unexpected diagnostics: %v", diags)
+			}
+
+			if stringValue(request.OriginHost) != stringValue(tt.wantOriginHost) || (request.OriginHost == nil) != (tt.wantOriginHost == nil) {
+				t.Errorf("origin host = %v, want %v", request.OriginHost, tt.wantOriginHost)
+			}
+			if len(request.Origin) != 1 {
+				t.Errorf("origins = %d, want 1", len(request.Origin))
+			}
+		})
+	}
+}
+
+func TestExpandCdnDomainPriorOriginConfig(t *testing.T) {
+	request := expandCdnDomainPriorOriginConfig("www.example.com", cdnDomainOriginConfig{
+		OriginProtocol: "http",
+	})
+
+	if request.OriginHost == nil || *request.OriginHost != "" {
+		t.Errorf("origin host = %v, want cleared", request.OriginHost)
+	}
+	if request.OriginSni == nil || request.OriginSni.Switch == nil || *request.OriginSni.Switch {
+		t.Errorf("origin SNI = %+v, want disabled", request.OriginSni)
+	}
+}
@@ -0,0 +1,285 @@
+package byteplus
+
+import (
+	"context"
+	"strings"
+
+	byteplusCdnClient "github.com/byteplus-sdk/byteplus-sdk-golang/service/cdn"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ resource.Resource                   = &cdnPreloadResource{}
+	_ resource.ResourceWithConfigure      = &cdnPreloadResource{}
+	_ resource.ResourceWithValidateConfig = &cdnPreloadResource{}
+)
+
+func NewCdnPreloadResource() resource.Resource {
+	return &cdnPreloadResource{}
+}
+
+type cdnPreloadResource struct {
+	clients *clientFactory
+}
+
+type cdnPreloadResourceModel struct {
+	ClientConfig      *resourceClientConfig `tfsdk:"client_config"`
+	Id                types.String          `tfsdk:"id"`
+	Urls              types.List            `tfsdk:"urls"`
+	Triggers          types.Map             `tfsdk:"triggers"`
+	WaitForCompletion types.Bool            `tfsdk:"wait_for_completion"`
+	WaitTimeout       types.String          `tfsdk:"wait_timeout"`
+	TaskIds           types.List            `tfsdk:"task_ids"`
+	QuotaLimit        types.Int64           `tfsdk:"quota_limit"`
+	QuotaRemaining    types.Int64           `tfsdk:"quota_remaining"`
+	Results           types.List            `tfsdk:"results"`
+}
+
+type cdnPreloadResultModel struct {
+	Url    types.String `tfsdk:"url"`
+	TaskId types.String `tfsdk:"task_id"`
+	Status types.String `tfsdk:"status"`
+}
+
+var cdnPreloadResultType = types.ObjectType{
+	AttrTypes: map[string]attr.Type{
+		"url":     types.StringType,
+		"task_id": types.StringType,
+		"status":  types.StringType,
+	},
+}
+
+func (r *cdnPreloadResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_cdn_preload"
+}
+
+func (r *cdnPreloadResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Submits CDN preload tasks to warm up the caches of URLs. The tasks are submitted " +
+			"again whenever `urls` or `triggers` changes, and nothing is done when the resource is destroyed. " +
+			"Creation fails without submitting anything if the daily quota is not enough. Once submitted, " +
+			"the tasks are recorded in state, and failing to submit the rest of the URLs, to describe the " +
+			"tasks or any URL failed to preload fails the creation and taints the resource, so all the URLs " +
+			"are submitted again on the next apply. Without `wait_for_completion`, the tasks are described " +
+			"once after submitting, so the URLs still running are not checked.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Description: "The IDs of the preload tasks separated by commas.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"urls": schema.ListAttribute{
+				Description: "The URLs of the files to preload, e.g. `https://www.example.com/video.mp4`. " +
+					"The URLs are submitted in batches of 1000, the limit of a single request.",
+				Required:    true,
+				ElementType: types.StringType,
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.RequiresReplace(),
+				},
+			},
+			"triggers": schema.MapAttribute{
+				Description: "Arbitrary values that submit the preload tasks again when changed, " +
+					"e.g. the version of a deployment.",
+				Optional:    true,
+				ElementType: types.StringType,
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplace(),
+				},
+			},
+			"wait_for_completion": schema.BoolAttribute{
+				Description: "Whether to wait until the preload tasks complete. Default to `true`.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(true),
+			},
+			"wait_timeout": schema.StringAttribute{
+				Description: "The maximum time to wait for the preload tasks to complete, e.g. `10m`. " +
+					"Default to `20m`.",
+				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString("20m"),
+			},
+			"task_ids": schema.ListAttribute{
+				Description: "The IDs of the preload tasks, one for each batch of URLs.",
+				Computed:    true,
+				ElementType: types.StringType,
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"quota_limit": schema.Int64Attribute{
+				Description: "The daily quota of the URLs to preload.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"quota_remaining": schema.Int64Attribute{
+				Description: "The remaining daily quota of the URLs to preload after the tasks are submitted.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"results": schema.ListNestedAttribute{
+				Description: "The results of the URLs when the tasks are submitted, or when they complete " +
+					"if `wait_for_completion` is `true`.",
+				Computed: true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"url": schema.StringAttribute{
+							Description: "The URL to preload.",
+							Computed:    true,
+						},
+						"task_id": schema.StringAttribute{
+							Description: "The ID of the preload task of the URL.",
+							Computed:    true,
+						},
+						"status": schema.StringAttribute{
+							Description: "The status of preloading the URL, `running`, `complete` or `failed`.",
+							Computed:    true,
+						},
+					},
+				},
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"client_config": resourceClientConfigBlock("CDN domains", "preload CDN caches"),
+		},
+	}
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *cdnPreloadResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	r.clients = req.ProviderData.(byteplusClients).clients
+}
+
+// ValidateConfig validates the URLs and the wait timeout.
+func (r *cdnPreloadResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config *cdnPreloadResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	validateContentUrls(&resp.Diagnostics, path.Root("urls"), config.Urls, false)
+	parseWaitTimeout(&resp.Diagnostics, path.Root("wait_timeout"), config.WaitTimeout, cdnContentTaskTimeout)
+}
+
+// Create submits the preload tasks and waits until they complete.
+func (r *cdnPreloadResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan *cdnPreloadResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(plan.ClientConfig.setWriteOnlyKeys(ctx, req.Config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	client, clientDiags := r.clients.cdnClient(plan.ClientConfig.toClientConfig())
+	resp.Diagnostics.Append(clientDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var urls []string
+	resp.Diagnostics.Append(plan.Urls.ElementsAs(ctx, &urls, false)...)
+	waitTimeout := parseWaitTimeout(&resp.Diagnostics, path.Root("wait_timeout"), plan.WaitTimeout, cdnContentTaskTimeout)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	urls = uniqueStrings(urls)
+
+	result, diags := runCdnContentTasks(ctx, client, cdnContentTaskRun{
+		operation: "Preload",
+		action:    "SubmitPreloadTask",
+		taskType:  cdnContentTaskTypePreload,
+		batchSize: cdnPreloadUrlsPerRequest,
+		submit: func(batch string) (string, error) {
+			response, err := client.SubmitPreloadTask(&byteplusCdnClient.SubmitPreloadTaskRequest{
+				Urls: batch,
+			})
+			if err != nil {
+				return "", err
+			}
+			return response.Result.TaskID, nil
+		},
+	}, urls, plan.WaitForCompletion.ValueBool(), waitTimeout)
+	resp.Diagnostics.Append(diags...)
+	if len(result.taskIds) == 0 {
+		return
+	}
+
+	// Record the submitted tasks and their results even with errors, the
+	// resource is then tainted and the URLs are submitted again on the next
+	// apply.
+	state := *plan
+	state.Id = types.StringValue(strings.Join(result.taskIds, ","))
+	taskIdsValue, diags := types.ListValueFrom(ctx, types.StringType, result.taskIds)
+	resp.Diagnostics.Append(diags...)
+	state.TaskIds = taskIdsValue
+	state.QuotaLimit = types.Int64Null()
+	state.QuotaRemaining = types.Int64Null()
+	if result.quota != nil {
+		state.QuotaLimit = types.Int64Value(result.quota.limit)
+		state.QuotaRemaining = types.Int64Value(result.quota.remain)
+	}
+
+	results := make([]cdnPreloadResultModel, 0, len(result.tasks))
+	for _, task := range result.tasks {
+		results = append(results, cdnPreloadResultModel{
+			Url:    types.StringValue(task.Url),
+			TaskId: types.StringValue(task.TaskID),
+			Status: types.StringValue(task.Status),
+		})
+	}
+	resultsValue, diags := types.ListValueFrom(ctx, cdnPreloadResultType, results)
+	resp.Diagnostics.Append(diags...)
+	state.Results = resultsValue
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Read does nothing, the preload tasks are one-off and cannot drift.
+func (r *cdnPreloadResource) Read(_ context.Context, _ resource.ReadRequest, _ *resource.ReadResponse) {
+}
+
+// Update only changes the wait options, the other changes submit the preload
+// tasks again with replacement.
+func (r *cdnPreloadResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan *cdnPreloadResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, plan)...)
+}
+
+// Delete only removes the preload tasks from state, the preloaded caches
+// expire as usual.
+func (r *cdnPreloadResource) Delete(_ context.Context, _ resource.DeleteRequest, _ *resource.DeleteResponse) {
+}
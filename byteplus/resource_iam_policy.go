@@ -35,14 +35,16 @@ func NewIamPolicyResource() resource.Resource {
 }
 
 type iamPolicyResource struct {
-	client *byteplusIamClient.IAM
+	client  *byteplusIamClient.IAM
+	clients *clientFactory
 }
 
 type iamPolicyResourceModel struct {
-	UserName               types.String    `tfsdk:"user_name"`
-	AttachedPolicies       types.List      `tfsdk:"attached_policies"`
-	AttachedPoliciesDetail []*policyDetail `tfsdk:"attached_policies_detail"`
-	CombinedPolicesDetail  []*policyDetail `tfsdk:"combined_policies_detail"`
+	ClientConfig           *resourceClientConfig `tfsdk:"client_config"`
+	UserName               types.String          `tfsdk:"user_name"`
+	AttachedPolicies       types.List            `tfsdk:"attached_policies"`
+	AttachedPoliciesDetail []*policyDetail       `tfsdk:"attached_policies_detail"`
+	CombinedPolicesDetail  []*policyDetail       `tfsdk:"combined_policies_detail"`
 }
 
 type policyDetail struct {
@@ -104,6 +106,9 @@ func (r *iamPolicyResource) Schema(_ context.Context, _ resource.SchemaRequest,
 				},
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"client_config": resourceClientConfigBlock("IAM client", "manage IAM policies"),
+		},
 	}
 }
 
@@ -112,7 +117,33 @@ func (r *iamPolicyResource) Configure(_ context.Context, req resource.ConfigureR
 	if req.ProviderData == nil {
 		return
 	}
-	r.client = req.ProviderData.(byteplusClients).iamClient
+	r.clients = req.ProviderData.(byteplusClients).clients
+	r.client = r.clients.providerIamClient
+}
+
+// withClientConfig returns the resource with the IAM client of the
+// client_config block, or the resource itself if the block is not set.
+//
+// Parameters:
+//   - config: The client_config block of the plan or state.
+//
+// Returns:
+//   - policyResource: The resource to manage the policies with.
+//   - diags: Diagnostics of creating the client.
+func (r *iamPolicyResource) withClientConfig(config *clientConfig) (policyResource *iamPolicyResource, diags diag.Diagnostics) {
+	if !isClientConfigSet(config) {
+		return r, nil
+	}
+
+	client, diags := r.clients.iamClient(config)
+	if diags.HasError() {
+		return
+	}
+
+	return &iamPolicyResource{
+		client:  client,
+		clients: r.clients,
+	}, diags
 }
 
 // Create implements resource.Resource.
@@ -124,7 +155,13 @@ func (r *iamPolicyResource) Create(ctx context.Context, req resource.CreateReque
 		return
 	}
 
-	combinedPolicies, attachedPolicies, errors := r.createPolicy(ctx, plan)
+	policyResource, clientDiags := r.withClientConfig(plan.ClientConfig.toClientConfig())
+	resp.Diagnostics.Append(clientDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	combinedPolicies, attachedPolicies, errors := policyResource.createPolicy(ctx, plan)
 	addDiagnostics(
 		&resp.Diagnostics,
 		"error",
@@ -137,12 +174,13 @@ func (r *iamPolicyResource) Create(ctx context.Context, req resource.CreateReque
 	}
 
 	state := &iamPolicyResourceModel{}
+	state.ClientConfig = plan.ClientConfig
 	state.UserName = plan.UserName
 	state.AttachedPolicies = plan.AttachedPolicies
 	state.AttachedPoliciesDetail = attachedPolicies
 	state.CombinedPolicesDetail = combinedPolicies
 
-	err := r.attachPolicyToUser(state)
+	err := policyResource.attachPolicyToUser(ctx, state)
 	addDiagnostics(
 		&resp.Diagnostics,
 		"error",
@@ -155,7 +193,7 @@ func (r *iamPolicyResource) Create(ctx context.Context, req resource.CreateReque
 	}
 
 	// Create policy are not expected to have not found warning.
-	readCombinedPolicyNotExistErr, readCombinedPolicyErr := r.readCombinedPolicy(state)
+	readCombinedPolicyNotExistErr, readCombinedPolicyErr := policyResource.readCombinedPolicy(ctx, state)
 	addDiagnostics(
 		&resp.Diagnostics,
 		"error",
@@ -197,7 +235,13 @@ func (r *iamPolicyResource) Read(ctx context.Context, req resource.ReadRequest,
 		return
 	}
 
-	readCombinedPolicyNotExistErr, readCombinedPolicyErr := r.readCombinedPolicy(state)
+	policyResource, clientDiags := r.withClientConfig(state.ClientConfig.toClientConfig())
+	resp.Diagnostics.Append(clientDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	readCombinedPolicyNotExistErr, readCombinedPolicyErr := policyResource.readCombinedPolicy(ctx, state)
 	addDiagnostics(
 		&resp.Diagnostics,
 		"warning",
@@ -223,7 +267,7 @@ func (r *iamPolicyResource) Read(ctx context.Context, req resource.ReadRequest,
 	// If the attached policy not found, it should return warning instead of error
 	// because there is no ways to get plan configuration in Read() function to
 	// indicate user had removed the non existed policies from the input.
-	readAttachedPolicyNotExistErr, readAttachedPolicyErr := r.readAttachedPolicy(state)
+	readAttachedPolicyNotExistErr, readAttachedPolicyErr := policyResource.readAttachedPolicy(ctx, state)
 	addDiagnostics(
 		&resp.Diagnostics,
 		"warning",
@@ -280,9 +324,29 @@ func (r *iamPolicyResource) Update(ctx context.Context, req resource.UpdateReque
 		return
 	}
 
+	// The old policies are removed with the client of the state, and the new
+	// policies are created with the client of the plan. The keys are not
+	// recorded in state, so the keys in the configuration are used for both.
+	resp.Diagnostics.Append(state.ClientConfig.setWriteOnlyKeys(ctx, req.Config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	statePolicyResource, clientDiags := r.withClientConfig(state.ClientConfig.toClientConfig())
+	resp.Diagnostics.Append(clientDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	policyResource, clientDiags := r.withClientConfig(plan.ClientConfig.toClientConfig())
+	resp.Diagnostics.Append(clientDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// Make sure each of the attached policies are exist before removing the combined
 	// policies.
-	readAttachedPolicyNotExistErr, readAttachedPolicyErr := r.readAttachedPolicy(plan)
+	readAttachedPolicyNotExistErr, readAttachedPolicyErr := policyResource.readAttachedPolicy(ctx, plan)
 	addDiagnostics(
 		&resp.Diagnostics,
 		"error",
@@ -301,7 +365,7 @@ func (r *iamPolicyResource) Update(ctx context.Context, req resource.UpdateReque
 		return
 	}
 
-	removePolicyDiags := r.removePolicy(state)
+	removePolicyDiags := statePolicyResource.removePolicy(ctx, state)
 	resp.Diagnostics.Append(removePolicyDiags...)
 	if resp.Diagnostics.HasError() {
 		return
@@ -314,7 +378,7 @@ func (r *iamPolicyResource) Update(ctx context.Context, req resource.UpdateReque
 		return
 	}
 
-	combinedPolicies, attachedPolicies, errors := r.createPolicy(ctx, plan)
+	combinedPolicies, attachedPolicies, errors := policyResource.createPolicy(ctx, plan)
 	addDiagnostics(
 		&resp.Diagnostics,
 		"error",
@@ -326,12 +390,13 @@ func (r *iamPolicyResource) Update(ctx context.Context, req resource.UpdateReque
 		return
 	}
 
+	state.ClientConfig = plan.ClientConfig
 	state.UserName = plan.UserName
 	state.AttachedPolicies = plan.AttachedPolicies
 	state.AttachedPoliciesDetail = attachedPolicies
 	state.CombinedPolicesDetail = combinedPolicies
 
-	err := r.attachPolicyToUser(state)
+	err := policyResource.attachPolicyToUser(ctx, state)
 	addDiagnostics(
 		&resp.Diagnostics,
 		"error",
@@ -344,7 +409,7 @@ func (r *iamPolicyResource) Update(ctx context.Context, req resource.UpdateReque
 	}
 
 	// Create policy are not expected to have not found warning.
-	readCombinedPolicyNotExistErr, readCombinedPolicyErr := r.readCombinedPolicy(state)
+	readCombinedPolicyNotExistErr, readCombinedPolicyErr := policyResource.readCombinedPolicy(ctx, state)
 	addDiagnostics(
 		&resp.Diagnostics,
 		"error",
@@ -378,7 +443,13 @@ func (r *iamPolicyResource) Delete(ctx context.Context, req resource.DeleteReque
 		return
 	}
 
-	removePolicyDiags := r.removePolicy(state)
+	policyResource, clientDiags := r.withClientConfig(state.ClientConfig.toClientConfig())
+	resp.Diagnostics.Append(clientDiags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	removePolicyDiags := policyResource.removePolicy(ctx, state)
 	resp.Diagnostics.Append(removePolicyDiags...)
 	if resp.Diagnostics.HasError() {
 		return
@@ -399,12 +470,14 @@ func (r *iamPolicyResource) Delete(ctx context.Context, req resource.DeleteReque
 func (r *iamPolicyResource) createPolicy(ctx context.Context, plan *iamPolicyResourceModel) (combinedPoliciesDetail []*policyDetail, attachedPoliciesDetail []*policyDetail, errList []error) {
 	var policies []string
 	plan.AttachedPolicies.ElementsAs(ctx, &policies, false)
-	combinedPolicyDocuments, excludedPolicies, attachedPoliciesDetail, errList := r.combinePolicyDocument(policies)
+	combinedPolicyDocuments, excludedPolicies, attachedPoliciesDetail, errList := r.combinePolicyDocument(ctx, policies)
 	if errList != nil {
 		return nil, nil, errList
 	}
 
+	attempt := 0
 	createPolicy := func() error {
+		attempt++
 		for i, policy := range combinedPolicyDocuments {
 			policyName := fmt.Sprintf("%s-%d", plan.UserName.ValueString(), i+1)
 
@@ -413,7 +486,11 @@ func (r *iamPolicyResource) createPolicy(ctx context.Context, plan *iamPolicyRes
 				PolicyDocument: byteplus.String(policy),
 			}
 
-			if _, err := r.client.CreatePolicy(createPolicyRequest); err != nil {
+			err := logIamApiCall(ctx, r.client, "CreatePolicy", attempt, func() (err error) {
+				_, err = r.client.CreatePolicy(createPolicyRequest)
+				return
+			})
+			if err != nil {
 				return handleAPIError(err)
 			}
 		}
@@ -449,6 +526,7 @@ func (r *iamPolicyResource) createPolicy(ctx context.Context, plan *iamPolicyRes
 // combinePolicyDocument combine the policy with custom logic.
 //
 // Parameters:
+//   - ctx: Context.
 //   - attachedPolicies: List of user attached policies to be combined.
 //
 // Returns:
@@ -456,8 +534,8 @@ func (r *iamPolicyResource) createPolicy(ctx context.Context, plan *iamPolicyRes
 //   - excludedPolicies: If the target policy exceeds maximum length, then do not combine the policy and return as excludedPolicies.
 //   - attachedPoliciesDetail: The attached policies detail to be recorded in state file.
 //   - errList: List of errors, return nil if no errors.
-func (r *iamPolicyResource) combinePolicyDocument(attachedPolicies []string) (combinedPolicyDocument []string, excludedPolicies []*policyDetail, attachedPoliciesDetail []*policyDetail, errList []error) {
-	attachedPoliciesDetail, notExistErrList, unexpectedErrList := r.fetchPolicies(attachedPolicies, []string{"Custom", "System"})
+func (r *iamPolicyResource) combinePolicyDocument(ctx context.Context, attachedPolicies []string) (combinedPolicyDocument []string, excludedPolicies []*policyDetail, attachedPoliciesDetail []*policyDetail, errList []error) {
+	attachedPoliciesDetail, notExistErrList, unexpectedErrList := r.fetchPolicies(ctx, attachedPolicies, []string{"Custom", "System"})
 
 	errList = append(errList, notExistErrList...)
 	errList = append(errList, unexpectedErrList...)
@@ -527,18 +605,19 @@ func (r *iamPolicyResource) combinePolicyDocument(attachedPolicies []string) (co
 // readCombinedPolicy will read the combined policy details.
 //
 // Parameters:
+//   - ctx: Context.
 //   - state: The state configurations, it will directly update the value of the struct since it is a pointer.
 //
 // Returns:
 //   - notExistError: List of allowed not exist errors to be used as warning messages instead, return nil if no errors.
 //   - unexpectedError: List of unexpected errors to be used as normal error messages, return nil if no errors.
-func (r *iamPolicyResource) readCombinedPolicy(state *iamPolicyResourceModel) (notExistErrs, unexpectedErrs []error) {
+func (r *iamPolicyResource) readCombinedPolicy(ctx context.Context, state *iamPolicyResourceModel) (notExistErrs, unexpectedErrs []error) {
 	var policiesName []string
 	for _, policy := range state.CombinedPolicesDetail {
 		policiesName = append(policiesName, policy.PolicyName.ValueString())
 	}
 
-	policyDetails, notExistErrs, unexpectedErrs := r.fetchPolicies(policiesName, []string{"Custom"})
+	policyDetails, notExistErrs, unexpectedErrs := r.fetchPolicies(ctx, policiesName, []string{"Custom"})
 	if len(unexpectedErrs) > 0 {
 		return nil, unexpectedErrs
 	}
@@ -558,18 +637,19 @@ func (r *iamPolicyResource) readCombinedPolicy(state *iamPolicyResourceModel) (n
 // readAttachedPolicy will read the attached policy details.
 //
 // Parameters:
+//   - ctx: Context.
 //   - state: The state configurations, it will directly update the value of the struct since it is a pointer.
 //
 // Returns:
 //   - notExistError: List of allowed not exist errors to be used as warning messages instead, return nil if no errors.
 //   - unexpectedError: List of unexpected errors to be used as normal error messages, return nil if no errors.
-func (r *iamPolicyResource) readAttachedPolicy(state *iamPolicyResourceModel) (notExistErrs, unexpectedErrs []error) {
+func (r *iamPolicyResource) readAttachedPolicy(ctx context.Context, state *iamPolicyResourceModel) (notExistErrs, unexpectedErrs []error) {
 	var policiesName []string
 	for _, policyName := range state.AttachedPolicies.Elements() {
 		policiesName = append(policiesName, strings.Trim(policyName.String(), "\""))
 	}
 
-	policyDetails, notExistErrs, unexpectedErrs := r.fetchPolicies(policiesName, []string{"Custom", "System"})
+	policyDetails, notExistErrs, unexpectedErrs := r.fetchPolicies(ctx, policiesName, []string{"Custom", "System"})
 	if len(unexpectedErrs) > 0 {
 		return nil, unexpectedErrs
 	}
@@ -589,6 +669,7 @@ func (r *iamPolicyResource) readAttachedPolicy(state *iamPolicyResourceModel) (n
 // fetchPolicies retrieve policy document through BytePlus SDK with backoff retry.
 //
 // Parameters:
+//   - ctx: Context.
 //   - policiesName: List of IAM policies name.
 //   - policyTypes: List of IAM policy types to retrieve.
 //
@@ -596,18 +677,23 @@ func (r *iamPolicyResource) readAttachedPolicy(state *iamPolicyResourceModel) (n
 //   - policiesDetail: List of retrieved policies detail.
 //   - notExistError: List of allowed not exist errors to be used as warning messages instead, return empty list if no errors.
 //   - unexpectedError: List of unexpected errors to be used as normal error messages, return empty list if no errors.
-func (r *iamPolicyResource) fetchPolicies(policiesName []string, policyTypes []string) (policiesDetail []*policyDetail, notExistError, unexpectedError []error) {
+func (r *iamPolicyResource) fetchPolicies(ctx context.Context, policiesName []string, policyTypes []string) (policiesDetail []*policyDetail, notExistError, unexpectedError []error) {
 	for _, attachedPolicy := range policiesName {
 		getPolicyResponse := &byteplusIamClient.GetPolicyOutput{}
 		var err error
 
+		attempt := 0
 		getPolicy := func() error {
+			attempt++
 			for _, iamPolicyType := range policyTypes {
 				getPolicyRequest := &byteplusIamClient.GetPolicyInput{
 					PolicyName: byteplus.String(strings.Trim(attachedPolicy, "\"")),
 					PolicyType: byteplus.String(iamPolicyType),
 				}
-				getPolicyResponse, err = r.client.GetPolicy(getPolicyRequest)
+				err = logIamApiCall(ctx, r.client, "GetPolicy", attempt, func() (err error) {
+					getPolicyResponse, err = r.client.GetPolicy(getPolicyRequest)
+					return
+				})
 				if err != nil {
 					// If policy not found, then continue to next policy type.
 					if err.(bytepluserr.Error).Code() == "PolicyNotExist" {
@@ -687,9 +773,12 @@ func (r *iamPolicyResource) checkPoliciesDrift(newState, oriState *iamPolicyReso
 // removePolicy will detach and delete the combined policies from user.
 //
 // Parameters:
+//   - ctx: Context.
 //   - state: The recorded state configurations.
-func (r *iamPolicyResource) removePolicy(state *iamPolicyResourceModel) diag.Diagnostics {
+func (r *iamPolicyResource) removePolicy(ctx context.Context, state *iamPolicyResourceModel) diag.Diagnostics {
+	attempt := 0
 	removePolicy := func() error {
+		attempt++
 		for _, combinedPolicy := range state.CombinedPolicesDetail {
 			detachPolicyFromUserRequest := &byteplusIamClient.DetachUserPolicyInput{
 				PolicyType: byteplus.String("Custom"),
@@ -701,11 +790,19 @@ func (r *iamPolicyResource) removePolicy(state *iamPolicyResourceModel) diag.Dia
 				PolicyName: byteplus.String(combinedPolicy.PolicyName.ValueString()),
 			}
 
-			if _, err := r.client.DetachUserPolicy(detachPolicyFromUserRequest); err != nil {
+			err := logIamApiCall(ctx, r.client, "DetachUserPolicy", attempt, func() (err error) {
+				_, err = r.client.DetachUserPolicy(detachPolicyFromUserRequest)
+				return
+			})
+			if err != nil {
 				return handleAPIError(err)
 			}
 
-			if _, err := r.client.DeletePolicy(deletePolicyRequest); err != nil {
+			err = logIamApiCall(ctx, r.client, "DeletePolicy", attempt, func() (err error) {
+				_, err = r.client.DeletePolicy(deletePolicyRequest)
+				return
+			})
+			if err != nil {
 				return handleAPIError(err)
 			}
 		}
@@ -731,12 +828,15 @@ func (r *iamPolicyResource) removePolicy(state *iamPolicyResourceModel) diag.Dia
 // attachPolicyToUser attach the IAM policy to user through BytePlus SDK.
 //
 // Parameters:
+//   - ctx: Context.
 //   - state: The recorded state configurations.
 //
 // Returns:
 //   - err: Error.
-func (r *iamPolicyResource) attachPolicyToUser(state *iamPolicyResourceModel) (err error) {
+func (r *iamPolicyResource) attachPolicyToUser(ctx context.Context, state *iamPolicyResourceModel) (err error) {
+	attempt := 0
 	attachPolicyToUser := func() error {
+		attempt++
 		for _, combinedPolicy := range state.CombinedPolicesDetail {
 			attachPolicyToUserRequest := &byteplusIamClient.AttachUserPolicyInput{
 				PolicyType: byteplus.String("Custom"),
@@ -744,7 +844,11 @@ func (r *iamPolicyResource) attachPolicyToUser(state *iamPolicyResourceModel) (e
 				UserName:   byteplus.String(state.UserName.ValueString()),
 			}
 
-			if _, err := r.client.AttachUserPolicy(attachPolicyToUserRequest); err != nil {
+			err := logIamApiCall(ctx, r.client, "AttachUserPolicy", attempt, func() (err error) {
+				_, err = r.client.AttachUserPolicy(attachPolicyToUserRequest)
+				return
+			})
+			if err != nil {
 				return handleAPIError(err)
 			}
 		}
@@ -0,0 +1,60 @@
+package byteplus
+
+import (
+	"testing"
+
+	"github.com/byteplus-sdk/byteplus-go-sdk-v2/byteplus/credentials"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestIamPolicyResourceWithClientConfig(t *testing.T) {
+	factory, err := newClientFactory(credentials.NewStaticCredentials("AKTEST", "SKTEST", ""), "", "ap-singapore-1", serviceEndpoints{}, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	r := &iamPolicyResource{
+		client:  factory.providerIamClient,
+		clients: factory,
+	}
+
+	policyResource, diags := r.withClientConfig(nil)
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	if policyResource != r {
+		t.Errorf("resource without client_config is not the resource itself")
+	}
+
+	otherKeys := &resourceClientConfig{
+		Region:       types.StringNull(),
+		AccessKey:    types.StringValue("AKOTHER"),
+		SecretKey:    types.StringValue("SKOTHER"),
+		SessionToken: types.StringNull(),
+	}
+	policyResource, diags = r.withClientConfig(otherKeys.toClientConfig())
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	if policyResource == r || policyResource.client == factory.providerIamClient {
+		t.Fatalf("resource with client_config keys uses the IAM client of the provider")
+	}
+	if r.client != factory.providerIamClient {
+		t.Errorf("IAM client of the resource is replaced by the client of client_config")
+	}
+
+	value, err := policyResource.client.Client.Config.Credentials.Get()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if value.AccessKeyID != "AKOTHER" {
+		t.Errorf("access key = %q, want %q", value.AccessKeyID, "AKOTHER")
+	}
+
+	samePolicyResource, diags := r.withClientConfig(otherKeys.toClientConfig())
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+	if samePolicyResource.client != policyResource.client {
+		t.Errorf("IAM client of the same client_config is not reused")
+	}
+}
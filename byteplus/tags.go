@@ -0,0 +1,138 @@
+package byteplus
+
+import (
+	"context"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// mergeTags merges the tags of a resource into the default tags of the
+// provider, the tags of the resource override the default tags with the same
+// key.
+//
+// Parameters:
+//   - defaultTags: The default tags of the provider.
+//   - tags: The tags of the resource.
+//
+// Returns:
+//   - tagsAll: The merged tags.
+func mergeTags(defaultTags, tags map[string]string) (tagsAll map[string]string) {
+	tagsAll = make(map[string]string, len(defaultTags)+len(tags))
+	for key, value := range defaultTags {
+		tagsAll[key] = value
+	}
+	for key, value := range tags {
+		tagsAll[key] = value
+	}
+
+	return
+}
+
+// containsTags returns whether the tags contain all the filter tags with the
+// same values.
+func containsTags(tags, filterTags map[string]string) bool {
+	for key, value := range filterTags {
+		if tagValue, ok := tags[key]; !ok || tagValue != value {
+			return false
+		}
+	}
+
+	return true
+}
+
+// modifyPlanTagsAll sets the `tags_all` attribute in the plan to the `tags` of
+// the resource merged into the default tags of the provider, so the effective
+// tags are visible at plan time. `tags_all` is unknown if any of the tags is
+// unknown.
+//
+// Parameters:
+//   - ctx: Context.
+//   - defaultTags: The default tags of the provider.
+//   - req: The plan modification request of the resource.
+//   - resp: The plan modification response of the resource.
+func modifyPlanTagsAll(ctx context.Context, defaultTags map[string]string, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Resource is being destroyed.
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var tags types.Map
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("tags"), &tags)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if tags.IsUnknown() {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("tags_all"), types.MapUnknown(types.StringType))...)
+		return
+	}
+
+	resourceTags := make(map[string]string)
+	for key, value := range tags.Elements() {
+		if value.IsUnknown() {
+			resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("tags_all"), types.MapUnknown(types.StringType))...)
+			return
+		}
+		if tagValue, ok := value.(types.String); ok {
+			resourceTags[key] = tagValue.ValueString()
+		}
+	}
+
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("tags_all"), mergeTags(defaultTags, resourceTags))...)
+}
+
+// modifyPlanProjectName sets the `project_name` attribute in the plan to the
+// default project of the provider if the resource does not set its own.
+//
+// Parameters:
+//   - ctx: Context.
+//   - defaultProjectName: The default project of the provider.
+//   - req: The plan modification request of the resource.
+//   - resp: The plan modification response of the resource.
+func modifyPlanProjectName(ctx context.Context, defaultProjectName string, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Resource is being destroyed.
+	if req.Plan.Raw.IsNull() || defaultProjectName == "" {
+		return
+	}
+
+	var projectName types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("project_name"), &projectName)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if projectName.IsNull() {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("project_name"), defaultProjectName)...)
+	}
+}
+
+// diffTags returns the tags to add or update and the keys of the tags to remove
+// to change the tags from the old tags to the new tags.
+//
+// Parameters:
+//   - oldTags: The current tags of the resource.
+//   - newTags: The desired tags of the resource.
+//
+// Returns:
+//   - updatedTags: The tags that are added or whose values are changed.
+//   - removedKeys: The keys of the tags that are removed.
+func diffTags(oldTags, newTags map[string]string) (updatedTags map[string]string, removedKeys []string) {
+	updatedTags = make(map[string]string)
+	for key, value := range newTags {
+		if oldValue, ok := oldTags[key]; !ok || oldValue != value {
+			updatedTags[key] = value
+		}
+	}
+
+	for key := range oldTags {
+		if _, ok := newTags[key]; !ok {
+			removedKeys = append(removedKeys, key)
+		}
+	}
+	sort.Strings(removedKeys)
+
+	return
+}
@@ -0,0 +1,113 @@
+package byteplus
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMergeTags(t *testing.T) {
+	tests := []struct {
+		name        string
+		defaultTags map[string]string
+		tags        map[string]string
+		want        map[string]string
+	}{
+		{
+			name: "no tags",
+			want: map[string]string{},
+		},
+		{
+			name:        "default tags only",
+			defaultTags: map[string]string{"team": "cdn"},
+			want:        map[string]string{"team": "cdn"},
+		},
+		{
+			name:        "resource tags override default tags",
+			defaultTags: map[string]string{"team": "cdn", "env": "prod"},
+			tags:        map[string]string{"env": "staging", "owner": "alice"},
+			want:        map[string]string{"team": "cdn", "env": "staging", "owner": "alice"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := mergeTags(tt.defaultTags, tt.tags); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("mergeTags() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestContainsTags(t *testing.T) {
+	tags := map[string]string{"team": "cdn", "env": "prod"}
+
+	tests := []struct {
+		name       string
+		filterTags map[string]string
+		want       bool
+	}{
+		{name: "no filter", filterTags: nil, want: true},
+		{name: "subset", filterTags: map[string]string{"env": "prod"}, want: true},
+		{name: "all", filterTags: map[string]string{"team": "cdn", "env": "prod"}, want: true},
+		{name: "different value", filterTags: map[string]string{"env": "staging"}, want: false},
+		{name: "missing key", filterTags: map[string]string{"owner": "alice"}, want: false},
+		{name: "empty value of missing key", filterTags: map[string]string{"owner": ""}, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := containsTags(tags, tt.filterTags); got != tt.want {
+				t.Errorf("containsTags(%v) = %v, want %v", tt.filterTags, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDiffTags(t *testing.T) {
+	tests := []struct {
+		name            string
+		oldTags         map[string]string
+		newTags         map[string]string
+		wantUpdatedTags map[string]string
+		wantRemovedKeys []string
+	}{
+		{
+			name:            "unchanged",
+			oldTags:         map[string]string{"team": "cdn"},
+			newTags:         map[string]string{"team": "cdn"},
+			wantUpdatedTags: map[string]string{},
+		},
+		{
+			name:            "added",
+			oldTags:         nil,
+			newTags:         map[string]string{"team": "cdn"},
+			wantUpdatedTags: map[string]string{"team": "cdn"},
+		},
+		{
+			name:            "changed and removed",
+			oldTags:         map[string]string{"team": "cdn", "env": "prod", "owner": "alice", "cost": "1"},
+			newTags:         map[string]string{"team": "cdn", "env": "staging"},
+			wantUpdatedTags: map[string]string{"env": "staging"},
+			wantRemovedKeys: []string{"cost", "owner"},
+		},
+		{
+			name:            "all removed",
+			oldTags:         map[string]string{"team": "cdn", "env": "prod"},
+			newTags:         nil,
+			wantUpdatedTags: map[string]string{},
+			wantRemovedKeys: []string{"env", "team"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			updatedTags, removedKeys := diffTags(tt.oldTags, tt.newTags)
+			if !reflect.DeepEqual(updatedTags, tt.wantUpdatedTags) {
+				t.Errorf("updated tags = %v, want %v", updatedTags, tt.wantUpdatedTags)
+			}
+			if !reflect.DeepEqual(removedKeys, tt.wantRemovedKeys) {
+				t.Errorf("removed keys = %v, want %v", removedKeys, tt.wantRemovedKeys)
+			}
+		})
+	}
+}
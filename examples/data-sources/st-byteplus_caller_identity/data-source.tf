@@ -0,0 +1,5 @@
+data "st-byteplus_caller_identity" "current" {}
+
+output "account_id" {
+  value = data.st-byteplus_caller_identity.current.account_id
+}
@@ -0,0 +1,20 @@
+data "st-byteplus_cdn_certificate_info" "example" {
+  certificate = file("${path.module}/example.com.pem")
+  domain_name = "www.example.com"
+}
+
+resource "st-byteplus_cdn_certificate" "example" {
+  certificate = data.st-byteplus_cdn_certificate_info.example.certificate
+  private_key = file("${path.module}/example.com.key")
+
+  lifecycle {
+    precondition {
+      condition     = data.st-byteplus_cdn_certificate_info.example.covers_domain
+      error_message = "The certificate does not cover www.example.com."
+    }
+    precondition {
+      condition     = data.st-byteplus_cdn_certificate_info.example.chain_error == ""
+      error_message = data.st-byteplus_cdn_certificate_info.example.chain_error
+    }
+  }
+}
@@ -1,3 +1,14 @@
 data "st-byteplus_cdn_domain" "example" {
   domain_name = "www.example.com"
 }
+
+data "st-byteplus_cdn_domain" "wait_online" {
+  domain_name     = "www.example.com"
+  wait_for_status = "online"
+  wait_timeout    = "10m"
+}
+
+data "st-byteplus_cdn_domain" "strict" {
+  domain_name       = "www.example.com"
+  fail_if_not_found = true
+}
@@ -0,0 +1,16 @@
+data "st-byteplus_cdn_domains" "example" {
+  name_regex   = "\\.example\\.com$"
+  status       = "online"
+  service_type = "web"
+  project_name = "default"
+
+  tags = {
+    env = "production"
+  }
+}
+
+data "st-byteplus_cdn_domain" "example" {
+  for_each = toset(data.st-byteplus_cdn_domains.example.domain_names)
+
+  domain_name = each.value
+}
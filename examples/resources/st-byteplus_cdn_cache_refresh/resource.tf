@@ -0,0 +1,25 @@
+resource "st-byteplus_cdn_cache_refresh" "files" {
+  urls = [
+    "https://www.example.com/index.html",
+    "https://www.example.com/app.js",
+  ]
+
+  triggers = {
+    version = var.release_version
+  }
+}
+
+resource "st-byteplus_cdn_cache_refresh" "directories" {
+  type = "dir"
+  urls = ["https://www.example.com/static/"]
+
+  triggers = {
+    version = var.release_version
+  }
+
+  wait_timeout = "30m"
+}
+
+output "refresh_quota_remaining" {
+  value = st-byteplus_cdn_cache_refresh.files.quota_remaining
+}
@@ -0,0 +1,2 @@
+# CDN cache rules can be imported using the domain name, e.g.
+terraform import st-byteplus_cdn_cache_rules.example www.example.com
@@ -0,0 +1,31 @@
+resource "st-byteplus_cdn_cache_rules" "example" {
+  domain_name = st-byteplus_cdn_domain.example.domain_name
+
+  rule {
+    condition_type = "directory"
+    values         = ["/"]
+    ttl            = 3600
+
+    honour_origin_headers = true
+  }
+
+  rule {
+    condition_type = "suffix"
+    values         = ["jpg", "png", "css", "js"]
+    ttl            = 2592000
+
+    ignore_query_string = true
+  }
+
+  rule {
+    condition_type = "regex"
+    values         = ["^/api/v[0-9]+/"]
+    ttl            = 0
+  }
+
+  rule {
+    condition_type = "path"
+    values         = ["/index.html"]
+    ttl            = 300
+  }
+}
@@ -0,0 +1,9 @@
+resource "st-byteplus_cdn_certificate" "example" {
+  certificate = file("${path.module}/certs/fullchain.pem")
+  private_key = file("${path.module}/certs/privkey.pem")
+  description = "www.example.com"
+
+  lifecycle {
+    create_before_destroy = true
+  }
+}
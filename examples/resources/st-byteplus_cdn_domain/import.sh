@@ -0,0 +1,2 @@
+# CDN domain can be imported using the domain name, e.g.
+terraform import st-byteplus_cdn_domain.example www.example.com
@@ -0,0 +1,22 @@
+resource "st-byteplus_cdn_domain" "example" {
+  domain_name     = "www.example.com"
+  service_type    = "web"
+  service_region  = "outside_chinese_mainland"
+  origin_protocol = "http"
+
+  origin = [
+    {
+      address       = "1.1.1.1"
+      instance_type = "ip"
+    },
+    {
+      address       = "backup.example.com"
+      instance_type = "domain"
+      origin_type   = "backup"
+    },
+  ]
+
+  tags = {
+    env = "production"
+  }
+}
@@ -0,0 +1,2 @@
+# CDN domain HTTPS can be imported using the domain name, e.g.
+terraform import st-byteplus_cdn_domain_https.example www.example.com
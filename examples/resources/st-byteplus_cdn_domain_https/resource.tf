@@ -0,0 +1,24 @@
+resource "st-byteplus_cdn_certificate" "example" {
+  certificate = file("${path.module}/certs/fullchain.pem")
+  private_key = file("${path.module}/certs/privkey.pem")
+
+  lifecycle {
+    create_before_destroy = true
+  }
+}
+
+resource "st-byteplus_cdn_domain_https" "example" {
+  domain_name = st-byteplus_cdn_domain.example.domain_name
+  cert_id     = st-byteplus_cdn_certificate.example.id
+
+  http2        = true
+  tls_versions = ["tlsv1.2", "tlsv1.3"]
+  ocsp         = true
+
+  forced_redirect             = true
+  forced_redirect_status_code = "301"
+
+  hsts_enabled            = true
+  hsts_max_age            = 31536000
+  hsts_include_subdomains = false
+}
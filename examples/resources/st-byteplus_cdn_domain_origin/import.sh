@@ -0,0 +1,2 @@
+# CDN domain origins can be imported using the domain name, e.g.
+terraform import st-byteplus_cdn_domain_origin.example www.example.com
@@ -0,0 +1,42 @@
+resource "st-byteplus_cdn_domain" "example" {
+  domain_name  = "www.example.com"
+  service_type = "web"
+
+  # The origins are only required to add the domain, they are managed by
+  # st-byteplus_cdn_domain_origin afterwards.
+  origin = [
+    {
+      address       = "1.1.1.1"
+      instance_type = "ip"
+    },
+  ]
+
+  lifecycle {
+    ignore_changes = [origin]
+  }
+}
+
+resource "st-byteplus_cdn_domain_origin" "example" {
+  domain_name     = st-byteplus_cdn_domain.example.domain_name
+  origin_protocol = "https"
+  origin_host     = "origin.example.com"
+  origin_sni      = "origin.example.com"
+
+  origin = [
+    {
+      address       = "1.1.1.1"
+      instance_type = "ip"
+      weight        = 70
+    },
+    {
+      address       = "2.2.2.2"
+      instance_type = "ip"
+      weight        = 30
+    },
+    {
+      address       = "example-bucket.tos-ap-southeast-1.bytepluses.com"
+      instance_type = "tos"
+      origin_type   = "backup"
+    },
+  ]
+}
@@ -0,0 +1,17 @@
+resource "st-byteplus_cdn_preload" "example" {
+  urls = [
+    "https://www.example.com/videos/intro.mp4",
+    "https://www.example.com/downloads/installer.zip",
+  ]
+
+  triggers = {
+    version = var.release_version
+  }
+}
+
+output "preload_failed_urls" {
+  value = [
+    for result in st-byteplus_cdn_preload.example.results : result.url
+    if result.status == "failed"
+  ]
+}
@@ -2,3 +2,14 @@ resource "st-byteplus_iam_policy" "name" {
   user_name = "devopsuser01"
   attached_policies = ["VPCFullAccess", "TOSReadOnlyAccess", "VodReadOnlyAccess", "IAMFullAccess",]
 }
+
+resource "st-byteplus_iam_policy" "other_account" {
+  user_name         = "devopsuser01"
+  attached_policies = ["VPCFullAccess", "TOSReadOnlyAccess"]
+
+  client_config {
+    assume_role {
+      role_trn = "trn:iam::2100000000:role/terraform"
+    }
+  }
+}